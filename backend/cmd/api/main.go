@@ -4,6 +4,9 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 
@@ -26,16 +29,100 @@ func main() {
 	}
 
 	// Wiring de dependências
-	gameRepo := repository.NewInMemoryGameRepository()
-	groqSvc := service.NewGroqService(apiKey, "")
+	var gameRepo repository.GameRepository = repository.NewInMemoryGameRepository()
+	var tournamentRepo repository.TournamentRepository = repository.NewInMemoryTournamentRepository()
+	var ratingRepo repository.RatingRepository = repository.NewInMemoryRatingRepository()
+	var questionRepo repository.QuestionRepository = repository.NewInMemoryQuestionRepository()
+	var personaRepo repository.PersonaRepository = repository.NewInMemoryPersonaRepository()
+	if dir := os.Getenv("DATA_DIR"); dir != "" {
+		fileRepo, err := repository.NewFileGameRepository(filepath.Join(dir, "games"))
+		if err != nil {
+			log.Fatalf("erro a carregar os jogos de %s: %v", dir, err)
+		}
+		gameRepo = fileRepo
+		fileTournamentRepo, err := repository.NewFileTournamentRepository(filepath.Join(dir, "tournaments"))
+		if err != nil {
+			log.Fatalf("erro a carregar os torneios de %s: %v", dir, err)
+		}
+		tournamentRepo = fileTournamentRepo
+		fileRatingRepo, err := repository.NewFileRatingRepository(dir)
+		if err != nil {
+			log.Fatalf("erro a carregar as classificações de %s: %v", dir, err)
+		}
+		ratingRepo = fileRatingRepo
+		fileQuestionRepo, err := repository.NewFileQuestionRepository(filepath.Join(dir, "questions"))
+		if err != nil {
+			log.Fatalf("erro a carregar o banco de perguntas de %s: %v", dir, err)
+		}
+		questionRepo = fileQuestionRepo
+		filePersonaRepo, err := repository.NewFilePersonaRepository(filepath.Join(dir, "personas"))
+		if err != nil {
+			log.Fatalf("erro a carregar as personas de %s: %v", dir, err)
+		}
+		personaRepo = filePersonaRepo
+		log.Printf("jogos guardados em %s", dir)
+	}
+	prompts, err := service.LoadPromptLibrary(os.Getenv("PROMPTS_DIR"))
+	if err != nil {
+		log.Fatalf("erro a carregar os prompts: %v", err)
+	}
+	log.Printf("prompts disponíveis: %s", strings.Join(prompts.Names(), ", "))
 
-	createGameUC := usecase.NewCreateGameUseCase(gameRepo)
-	playRoundUC := usecase.NewPlayRoundUseCase(gameRepo, groqSvc)
+	model := os.Getenv("GROQ_MODEL")
+	if model == "" {
+		model = service.DefaultModel
+	}
+	contextLimits, err := service.ParseContextLimits(os.Getenv("GROQ_CONTEXT_LIMIT"), model)
+	if err != nil {
+		log.Fatalf("GROQ_CONTEXT_LIMIT: %v", err)
+	}
+	groqSvc := service.NewGroqService(apiKey, model, contextLimits, prompts)
 
-	gameHandler := handler.NewGameHandler(gameRepo, createGameUC, playRoundUC, groqSvc)
+	createGameUC := usecase.NewCreateGameUseCase(gameRepo, personaRepo, prompts, model)
+	questionBankUC := usecase.NewQuestionBankUseCase(questionRepo)
+	if n, err := questionBankUC.Seed(); err != nil {
+		log.Fatalf("erro a semear o banco de perguntas: %v", err)
+	} else if n > 0 {
+		log.Printf("banco de perguntas: %d perguntas embutidas", n)
+	}
+	questionMaxLength, _ := strconv.Atoi(os.Getenv("QUESTION_MAX_LENGTH"))
+	questionPolicy := usecase.QuestionPolicy{
+		MaxLength: questionMaxLength,
+		Classify:  os.Getenv("QUESTION_CLASSIFIER") == "true",
+	}
+	moderationPolicy := usecase.ModerationPolicy{
+		Mode:     os.Getenv("MODERATION_MODE"),
+		Classify: os.Getenv("MODERATION_CLASSIFIER") == "true",
+	}
+	if err := moderationPolicy.Validate(); err != nil {
+		log.Fatalf("MODERATION_MODE: %v", err)
+	}
+	if path := os.Getenv("MODERATION_BLOCKLIST"); path != "" {
+		moderationPolicy.Blocklist, err = readBlocklist(path)
+		if err != nil {
+			log.Fatalf("erro a ler a blocklist de %s: %v", path, err)
+		}
+		log.Printf("moderação: %d termos bloqueados", len(moderationPolicy.Blocklist))
+	}
+	ratingUC := usecase.NewRatingUseCase(ratingRepo, gameRepo)
+	playRoundUC := usecase.NewPlayRoundUseCase(gameRepo, groqSvc, questionBankUC, questionPolicy, moderationPolicy, ratingUC)
+	autoplayUC := usecase.NewAutoplayUseCase(gameRepo, playRoundUC)
+	personaLibraryUC := usecase.NewPersonaLibraryUseCase(personaRepo)
+	tournamentUC := usecase.NewTournamentUseCase(tournamentRepo, gameRepo, personaRepo, createGameUC, autoplayUC)
+	exportGameUC := usecase.NewExportGameUseCase(gameRepo)
+
+	gameHandler := handler.NewGameHandler(gameRepo, createGameUC, playRoundUC, autoplayUC, exportGameUC)
+	personaHandler := handler.NewPersonaHandler(personaRepo, personaLibraryUC)
+	questionHandler := handler.NewQuestionHandler(questionRepo, questionBankUC)
+	tournamentHandler := handler.NewTournamentHandler(gameRepo, tournamentUC)
+	leaderboardHandler := handler.NewLeaderboardHandler(ratingUC)
 
 	mux := http.NewServeMux()
 	gameHandler.RegisterRoutes(mux)
+	personaHandler.RegisterRoutes(mux)
+	questionHandler.RegisterRoutes(mux)
+	tournamentHandler.RegisterRoutes(mux)
+	leaderboardHandler.RegisterRoutes(mux)
 
 	addr := ":8080"
 	log.Printf("🔥 AI Hunger Games API a correr em http://localhost%s", addr)
@@ -43,3 +130,12 @@ func main() {
 		log.Fatalf("erro no servidor: %v", err)
 	}
 }
+
+func readBlocklist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return usecase.ReadBlocklist(f)
+}
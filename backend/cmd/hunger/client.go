@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+// client fala com a API HTTP do servidor.
+type client struct {
+	base string
+	http *http.Client
+}
+
+func newClient(base string) *client {
+	// Sem timeout: os streams duram uma ronda inteira (ou o jogo, no autoplay)
+	return &client{base: strings.TrimRight(base, "/"), http: &http.Client{}}
+}
+
+// apiError é uma resposta de erro do servidor.
+type apiError struct {
+	Status  int
+	Message string
+	Reasons []usecase.RejectReason // perguntas recusadas (422)
+}
+
+func (e *apiError) Error() string {
+	if len(e.Reasons) == 0 {
+		return e.Message
+	}
+	details := make([]string, 0, len(e.Reasons))
+	for _, r := range e.Reasons {
+		details = append(details, r.Detail)
+	}
+	return e.Message + ": " + strings.Join(details, "; ")
+}
+
+func (c *client) request(ctx context.Context, method, path string, body any) (*http.Response, error) {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, readAPIError(resp)
+	}
+	return resp, nil
+}
+
+func readAPIError(resp *http.Response) error {
+	data, _ := io.ReadAll(resp.Body)
+	e := &apiError{Status: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+
+	// O 422 de uma pergunta recusada vem em JSON, o resto em texto
+	var rejected struct {
+		Error   string                 `json:"error"`
+		Reasons []usecase.RejectReason `json:"reasons"`
+	}
+	if json.Unmarshal(data, &rejected) == nil && rejected.Error != "" {
+		e.Message, e.Reasons = rejected.Error, rejected.Reasons
+	}
+	if e.Message == "" {
+		e.Message = resp.Status
+	}
+	return e
+}
+
+// do envia um pedido e lê a resposta JSON para out (se não for nil).
+func (c *client) do(ctx context.Context, method, path string, body, out any) error {
+	resp, err := c.request(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// stream envia um pedido a um endpoint SSE e chama onEvent com cada evento,
+// até o servidor fechar o stream ou onEvent devolver um erro.
+func (c *client) stream(ctx context.Context, path string, body any, onEvent func(event string, data json.RawMessage) error) error {
+	resp, err := c.request(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Linhas grandes: round_end e game_end levam o jogo inteiro
+	r := bufio.NewReaderSize(resp.Body, 64*1024)
+	var event string
+	var data []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			if event != "" || len(data) > 0 {
+				if err := onEvent(event, json.RawMessage(strings.Join(data, "\n"))); err != nil {
+					return err
+				}
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
+
+func (c *client) createGame(ctx context.Context, req createGameRequest) (*domain.Game, error) {
+	var game domain.Game
+	if err := c.do(ctx, http.MethodPost, "/games", req, &game); err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+func (c *client) listGames(ctx context.Context) ([]*domain.Game, error) {
+	var games []*domain.Game
+	err := c.do(ctx, http.MethodGet, "/games", nil, &games)
+	return games, err
+}
+
+func (c *client) getGame(ctx context.Context, id string) (*domain.Game, error) {
+	var game domain.Game
+	if err := c.do(ctx, http.MethodGet, "/games/"+id, nil, &game); err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+func (c *client) submit(ctx context.Context, gameID string, round int, in usecase.HumanInput) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/games/%s/rounds/%d/submissions", gameID, round), in, nil)
+}
+
+func (c *client) pauseRound(ctx context.Context, gameID string) error {
+	return c.do(ctx, http.MethodPost, "/games/"+gameID+"/rounds/current/pause", nil, nil)
+}
+
+// createGameRequest é o body do POST /games (só os campos que o cliente usa).
+type createGameRequest struct {
+	NumAgents     int                  `json:"num_agents,omitempty"`
+	PersonaIDs    []string             `json:"persona_ids,omitempty"`
+	Models        []string             `json:"models,omitempty"`
+	MaxStrikes    int                  `json:"max_strikes,omitempty"`
+	FinaleDecider domain.FinaleDecider `json:"finale_decider,omitempty"`
+	HumanPlayers  []string             `json:"human_players,omitempty"`
+	HumanTimeout  int                  `json:"human_timeout_seconds,omitempty"`
+	Language      domain.Language      `json:"language,omitempty"`
+	PromptSet     string               `json:"prompt_set,omitempty"`
+	Theme         string               `json:"theme,omitempty"`
+	BlindVoting   bool                 `json:"blind_voting,omitempty"`
+	Seed          int64                `json:"seed,omitempty"`
+}
+
+// playRoundRequest é o body do POST /games/{id}/rounds/stream.
+type playRoundRequest struct {
+	Question     string `json:"question,omitempty"`
+	AutoQuestion bool   `json:"auto_question,omitempty"`
+}
+
+// autoplayRequest é o body do POST /games/{id}/autoplay.
+type autoplayRequest struct {
+	Source    usecase.QuestionSource `json:"source,omitempty"`
+	Questions []string               `json:"questions,omitempty"`
+	MaxRounds int                    `json:"max_rounds,omitempty"`
+}
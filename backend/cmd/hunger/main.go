@@ -0,0 +1,193 @@
+// Comando hunger: cliente de terminal da API. Cria e lista jogos, joga rondas
+// e mostra o stream SSE em direto, com cores e o estado dos agentes.
+//
+//	go run ./cmd/hunger new -agents 4 -strikes 2
+//	go run ./cmd/hunger play <id>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+const usage = `Uso: hunger [-server URL] [-no-color] <comando> [opções]
+
+Comandos:
+  new                cria um jogo
+  list               lista os jogos
+  show <id>          estado do jogo e dos agentes
+  play <id>          joga rondas, com as perguntas escritas no terminal
+  autoplay <id>      joga até ao fim com perguntas da lista, do banco ou geradas
+  resume <id>        retoma a ronda em pausa
+  retry <id>         repete a ronda que falhou
+
+"hunger <comando> -h" mostra as opções de cada comando.
+`
+
+func main() {
+	server := flag.String("server", envOr("HUNGER_SERVER", "http://localhost:8080"), "URL do servidor (ou HUNGER_SERVER)")
+	noColor := flag.Bool("no-color", false, "sem cores (também com NO_COLOR)")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	app := &app{
+		client: newClient(*server),
+		color:  !*noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout),
+	}
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+
+	var err error
+	switch cmd {
+	case "new":
+		err = app.newGame(args)
+	case "list", "ls":
+		err = app.list()
+	case "show":
+		err = app.show(args)
+	case "play":
+		err = app.play(args)
+	case "autoplay":
+		err = app.autoplay(args)
+	case "resume", "retry":
+		err = app.resume(cmd, args)
+	default:
+		fmt.Fprintf(os.Stderr, "comando desconhecido: %s\n\n", cmd)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "erro: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type app struct {
+	client *client
+	color  bool
+}
+
+func (a *app) newGame(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	agents := fs.Int("agents", 0, "número de agentes IA (0 = o do servidor)")
+	strikes := fs.Int("strikes", 0, "strikes até à eliminação")
+	personas := fs.String("personas", "", "IDs de personas da biblioteca, separados por vírgulas")
+	models := fs.String("models", "", "modelo de cada agente IA, separados por vírgulas")
+	humans := fs.String("humans", "", "nomes dos jogadores humanos, separados por vírgulas")
+	humanTimeout := fs.Int("human-timeout", 0, "segundos que cada humano tem por fase")
+	finale := fs.String("finale", "", "quem decide a final: judges, jury ou audience")
+	lang := fs.String("lang", "", "língua dos prompts: pt ou en")
+	promptSet := fs.String("prompt-set", "", "variante de prompts")
+	theme := fs.String("theme", "", "tema das perguntas geradas")
+	blind := fs.Bool("blind", false, "votação cega")
+	seed := fs.Int64("seed", 0, "semente das ordens baralhadas")
+	_ = fs.Parse(args)
+
+	game, err := a.client.createGame(context.Background(), createGameRequest{
+		NumAgents:     *agents,
+		PersonaIDs:    splitList(*personas),
+		Models:        splitList(*models),
+		MaxStrikes:    *strikes,
+		FinaleDecider: domain.FinaleDecider(*finale),
+		HumanPlayers:  splitList(*humans),
+		HumanTimeout:  *humanTimeout,
+		Language:      domain.Language(*lang),
+		PromptSet:     *promptSet,
+		Theme:         *theme,
+		BlindVoting:   *blind,
+		Seed:          *seed,
+	})
+	if err != nil {
+		return err
+	}
+
+	r := newRenderer(os.Stdout, a.color, game)
+	r.printf("Jogo criado: %s\n", r.paint(ansiBold, game.ID))
+	r.status()
+	r.printf("\nPara jogar: hunger play %s\n", game.ID)
+	return nil
+}
+
+func (a *app) list() error {
+	games, err := a.client.listGames(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(games) == 0 {
+		fmt.Println("Ainda não há jogos.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tESTADO\tRONDAS\tEM JOGO\tVENCEDOR")
+	for _, g := range games {
+		winner := "-"
+		if g.WinnerID != "" {
+			winner = agentName(g, g.WinnerID)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d/%d\t%s\n", g.ID, g.Status, len(g.Rounds), len(g.ActiveAgents()), len(g.Agents), winner)
+	}
+	return w.Flush()
+}
+
+func (a *app) show(args []string) error {
+	id, err := gameArg("show", args)
+	if err != nil {
+		return err
+	}
+	game, err := a.client.getGame(context.Background(), id)
+	if err != nil {
+		return err
+	}
+
+	r := newRenderer(os.Stdout, a.color, game)
+	r.printf("Jogo %s · %s · %d rondas · %d strikes para sair\n", r.paint(ansiBold, game.ID), game.Status, len(game.Rounds), game.MaxStrikes)
+	if cur := game.CurrentRound; cur != nil {
+		r.printf("Ronda %d a meio (%s): %s\n", cur.Index, cur.State, cur.Question)
+	}
+	r.status()
+	if game.Status == domain.GameStatusFinished {
+		r.gameEnd()
+	}
+	return nil
+}
+
+func gameArg(cmd string, args []string) (string, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return "", fmt.Errorf("falta o ID do jogo: hunger %s <id>", cmd)
+	}
+	return args[0], nil
+}
+
+func splitList(s string) []string {
+	var res []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			res = append(res, part)
+		}
+	}
+	return res
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// isTerminal diz se f é um terminal (e não um ficheiro ou um pipe).
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+// stdin é partilhado pelas perguntas e pelas intervenções do jogador humano.
+var stdin = bufio.NewReader(os.Stdin)
+
+// play joga rondas. Com -q ou -auto joga só uma; sem elas pede a pergunta de
+// cada ronda no terminal até o jogo acabar.
+func (a *app) play(args []string) error {
+	id, err := gameArg("play", args)
+	if err != nil {
+		return err
+	}
+	fs := flag.NewFlagSet("play", flag.ExitOnError)
+	question := fs.String("q", "", "pergunta da ronda (joga só essa ronda)")
+	auto := fs.Bool("auto", false, "pergunta gerada pelo apresentador (joga só essa ronda)")
+	as := fs.String("as", "", "ID do agente humano que jogas (por omissão o único humano do jogo)")
+	_ = fs.Parse(args[1:])
+	once := *question != "" || *auto
+
+	for played := false; ; played = true {
+		game, err := a.client.getGame(context.Background(), id)
+		if err != nil {
+			return err
+		}
+		r := newRenderer(os.Stdout, a.color, game)
+		if game.Status == domain.GameStatusFinished {
+			// Depois de uma ronda o game_end já mostrou a classificação
+			if !played {
+				r.gameEnd()
+			}
+			return nil
+		}
+		if cur := game.CurrentRound; cur != nil {
+			return fmt.Errorf("a ronda %d está a meio (%s): usa hunger resume %s ou hunger retry %s", cur.Index, cur.State, id, id)
+		}
+
+		req := playRoundRequest{Question: *question, AutoQuestion: *auto}
+		if !once {
+			r.printf("\n%s ", r.paint(ansiBold, fmt.Sprintf("Pergunta da ronda %d (Enter = o apresentador escolhe, q = sair):", game.NextRoundIndex())))
+			line, err := readLine()
+			if err != nil || line == "q" {
+				return nil
+			}
+			req.Question, req.AutoQuestion = line, line == ""
+		}
+
+		// O cabeçalho só aparece quando a ronda começa (a pergunta pode ser recusada)
+		index := game.NextRoundIndex()
+		r.onStart = func() { r.roundHeader(index, req.Question) }
+		err = a.stream(r, id, "/games/"+id+"/rounds/stream", req, a.player(game, *as), a.pause)
+		var apiErr *apiError
+		if !once && errors.As(err, &apiErr) && apiErr.Status == http.StatusUnprocessableEntity {
+			// Pergunta recusada: pede outra
+			r.printf("%s\n", r.paint(ansiRed, apiErr.Error()))
+			continue
+		}
+		if err != nil || once {
+			return err
+		}
+	}
+}
+
+// autoplay joga rondas seguidas até o jogo acabar (ou até -max rondas).
+func (a *app) autoplay(args []string) error {
+	id, err := gameArg("autoplay", args)
+	if err != nil {
+		return err
+	}
+	fs := flag.NewFlagSet("autoplay", flag.ExitOnError)
+	source := fs.String("source", "", "de onde vêm as perguntas: list, bank ou generate")
+	var questions stringList
+	fs.Var(&questions, "q", "pergunta da lista (repetível)")
+	maxRounds := fs.Int("max", 0, "número máximo de rondas")
+	as := fs.String("as", "", "ID do agente humano que jogas (por omissão o único humano do jogo)")
+	_ = fs.Parse(args[1:])
+
+	game, err := a.client.getGame(context.Background(), id)
+	if err != nil {
+		return err
+	}
+	r := newRenderer(os.Stdout, a.color, game)
+	return a.stream(r, id, "/games/"+id+"/autoplay", autoplayRequest{
+		Source:    usecase.QuestionSource(*source),
+		Questions: questions,
+		MaxRounds: *maxRounds,
+	}, a.player(game, *as), a.stopAutoplay)
+}
+
+// resume retoma a ronda em pausa (resume) ou repete a que falhou (retry).
+func (a *app) resume(cmd string, args []string) error {
+	id, err := gameArg(cmd, args)
+	if err != nil {
+		return err
+	}
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	as := fs.String("as", "", "ID do agente humano que jogas (por omissão o único humano do jogo)")
+	_ = fs.Parse(args[1:])
+
+	game, err := a.client.getGame(context.Background(), id)
+	if err != nil {
+		return err
+	}
+	// O cabeçalho da ronda vem no evento round_resumed/round_retried
+	r := newRenderer(os.Stdout, a.color, game)
+	return a.stream(r, id, "/games/"+id+"/rounds/current/"+cmd, nil, a.player(game, *as), a.pause)
+}
+
+// stream mostra os eventos de um endpoint SSE. O primeiro Ctrl+C chama
+// interrupt (pausa a ronda ou pára o autoplay) e o segundo sai logo.
+func (a *app) stream(r *renderer, gameID, path string, body any, p *player, interrupt func(gameID string) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt)
+	defer signal.Stop(sigs)
+	go func() {
+		select {
+		case <-sigs:
+		case <-ctx.Done():
+			return
+		}
+		fmt.Fprintln(os.Stderr, "\n(a parar no fim da intervenção em curso; Ctrl+C outra vez para sair já)")
+		if err := interrupt(gameID); err != nil {
+			fmt.Fprintf(os.Stderr, "erro: %v\n", err)
+		}
+		select {
+		case <-sigs:
+			os.Exit(130)
+		case <-ctx.Done():
+		}
+	}()
+
+	return a.client.stream(ctx, path, body, func(event string, data json.RawMessage) error {
+		r.event(event, data)
+		if event == "awaiting_input" && p != nil {
+			return p.respond(ctx, r, data)
+		}
+		return nil
+	})
+}
+
+func (a *app) pause(gameID string) error {
+	return a.client.pauseRound(context.Background(), gameID)
+}
+
+func (a *app) stopAutoplay(gameID string) error {
+	return a.client.do(context.Background(), http.MethodPost, "/games/"+gameID+"/autoplay/stop", nil, nil)
+}
+
+// player é o jogador humano que está no terminal.
+type player struct {
+	client  *client
+	gameID  string
+	agentID string
+}
+
+// player devolve o jogador do agente agentID, ou do único humano do jogo se
+// agentID estiver vazio. Sem humanos devolve nil.
+func (a *app) player(game *domain.Game, agentID string) *player {
+	if agentID == "" {
+		for _, ag := range game.Agents {
+			if !ag.Human {
+				continue
+			}
+			if agentID != "" {
+				return nil // mais do que um: é preciso -as
+			}
+			agentID = ag.ID
+		}
+	}
+	if agentID == "" {
+		return nil
+	}
+	return &player{client: a.client, gameID: game.ID, agentID: agentID}
+}
+
+// respond pede a intervenção ao jogador quando o servidor está à espera dela.
+func (p *player) respond(ctx context.Context, r *renderer, data json.RawMessage) error {
+	var req struct {
+		AgentID string       `json:"agent_id"`
+		Phase   domain.Phase `json:"phase"`
+		Round   int          `json:"round"`
+	}
+	if err := json.Unmarshal(data, &req); err != nil || req.AgentID != p.agentID {
+		return nil
+	}
+
+	for {
+		in := usecase.HumanInput{AgentID: p.agentID, Phase: req.Phase}
+		var err error
+		if req.Phase == domain.PhaseVote {
+			in.TargetID, in.Justification, err = p.askVote(r)
+		} else {
+			in.Text, err = p.ask(r, phasePrompts[req.Phase])
+		}
+		if err != nil {
+			return err
+		}
+
+		err = p.client.submit(ctx, p.gameID, req.Round, in)
+		var apiErr *apiError
+		switch {
+		case err == nil:
+			return nil
+		case errors.As(err, &apiErr) && apiErr.Status == http.StatusBadRequest:
+			r.printf("%s\n", r.paint(ansiRed, apiErr.Error()))
+		case errors.As(err, &apiErr) && apiErr.Status == http.StatusConflict:
+			// O tempo acabou entretanto
+			r.printf("%s\n", r.paint(ansiYellow, apiErr.Error()))
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+var phasePrompts = map[domain.Phase]string{
+	domain.PhaseAnswer: "A tua resposta:",
+	domain.PhaseDebate: "A tua intervenção no debate:",
+	domain.PhaseFinale: "A tua declaração:",
+}
+
+func (p *player) ask(r *renderer, prompt string) (string, error) {
+	r.printf("%s ", r.paint(ansiBold, prompt))
+	return readLine()
+}
+
+// askVote mostra os agentes em jogo e pede o número do alvo e a justificação.
+func (p *player) askVote(r *renderer) (string, string, error) {
+	var targets []string
+	for _, a := range r.game.ActiveAgents() {
+		if a.ID != p.agentID {
+			targets = append(targets, a.ID)
+			r.printf("  %d. %s\n", len(targets), r.name(a.ID))
+		}
+	}
+	for {
+		line, err := p.ask(r, "Em quem votas (número):")
+		if err != nil {
+			return "", "", err
+		}
+		n, err := strconv.Atoi(line)
+		if err == nil && n >= 1 && n <= len(targets) {
+			justification, err := p.ask(r, "Porquê:")
+			return targets[n-1], justification, err
+		}
+		r.printf("%s\n", r.paint(ansiRed, fmt.Sprintf("escolhe um número de 1 a %d", len(targets))))
+	}
+}
+
+// readLine lê uma linha do terminal, sem os espaços à volta.
+func readLine() (string, error) {
+	line, err := stdin.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimSpace(line), err
+}
+
+// stringList é uma flag que pode ser repetida.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, "; ") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
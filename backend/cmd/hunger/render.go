@@ -0,0 +1,425 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// Códigos ANSI usados pelo renderer.
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiDim    = "\033[2m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+)
+
+// agentColors são as cores dos agentes, pela ordem dos lugares.
+var agentColors = []string{"\033[36m", "\033[35m", "\033[33m", "\033[32m", "\033[34m", "\033[91m", "\033[96m", "\033[95m"}
+
+// lineWidth é a largura a que os textos dos agentes são partidos.
+const lineWidth = 88
+
+var phaseTitles = map[string]string{
+	"judge":                    "Juiz (empate)",
+	"audience_voting":          "Votação do público",
+	"finale_opening":           "Final: abertura",
+	"finale_cross_examination": "Final: contra-interrogatório",
+	"finale_closing":           "Final: alegações finais",
+	"finale_verdict":           "Final: veredicto",
+	"finale_tiebreak":          "Final: desempate",
+}
+
+var stageNames = map[domain.FinaleStage]string{
+	domain.FinaleStageOpening:          "abertura",
+	domain.FinaleStageCrossExamination: "contra-interrogatório",
+	domain.FinaleStageClosing:          "alegações finais",
+}
+
+// renderer escreve os eventos de uma ronda no terminal. Guarda o último estado
+// conhecido do jogo para mostrar os nomes dos agentes e o estado deles.
+type renderer struct {
+	out     io.Writer
+	color   bool
+	game    *domain.Game
+	section string // secção em curso, para só escrever o título uma vez
+	onStart func() // opcional, chamada antes do primeiro evento
+}
+
+func newRenderer(out io.Writer, color bool, game *domain.Game) *renderer {
+	return &renderer{out: out, color: color, game: game}
+}
+
+func (r *renderer) paint(code, s string) string {
+	if !r.color {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// name devolve o nome do agente com a cor do lugar dele.
+func (r *renderer) name(id string) string {
+	switch {
+	case id == "judge":
+		return r.paint(ansiBold, "Juiz")
+	case strings.HasPrefix(id, "judge-"):
+		return r.paint(ansiBold, "Juiz "+strings.TrimPrefix(id, "judge-"))
+	}
+	if r.game != nil {
+		for i, a := range r.game.Agents {
+			if a.ID == id {
+				return r.paint(ansiBold+agentColors[i%len(agentColors)], a.Name)
+			}
+		}
+	}
+	return id
+}
+
+func (r *renderer) printf(format string, args ...any) {
+	fmt.Fprintf(r.out, format, args...)
+}
+
+// title escreve o título da secção, se ainda não estiver nela.
+func (r *renderer) title(section, text string) {
+	if r.section == section {
+		return
+	}
+	r.section = section
+	r.printf("\n%s\n", r.paint(ansiBold, "── "+text+" ──"))
+}
+
+// say escreve a intervenção de um agente, com o texto partido e indentado.
+func (r *renderer) say(id, label, text string) {
+	head := r.name(id)
+	if label != "" {
+		head += r.paint(ansiDim, " ("+label+")")
+	}
+	r.printf("%s\n%s\n", head, wrap(text, lineWidth, "  "))
+}
+
+// event escreve um evento do stream. Os eventos que não conhece são ignorados.
+func (r *renderer) event(name string, data json.RawMessage) {
+	if r.onStart != nil {
+		r.onStart()
+		r.onStart = nil
+	}
+	switch name {
+	case "autoplay_round":
+		var p struct {
+			Round    int    `json:"round"`
+			Question string `json:"question"`
+		}
+		_ = json.Unmarshal(data, &p)
+		r.roundHeader(p.Round, p.Question)
+
+	case "question":
+		var p struct {
+			Round    int    `json:"round"`
+			Question string `json:"question"`
+		}
+		_ = json.Unmarshal(data, &p)
+		r.printf("%s %s\n", r.paint(ansiBold, "Pergunta do apresentador:"), p.Question)
+
+	case "answer":
+		var a domain.Answer
+		_ = json.Unmarshal(data, &a)
+		r.title("answers", "Respostas")
+		r.say(a.AgentID, "", a.Text)
+
+	case "debate":
+		var m domain.DebateMessage
+		_ = json.Unmarshal(data, &m)
+		r.title(fmt.Sprintf("debate-%d", m.Turn), fmt.Sprintf("Debate · turno %d", m.Turn))
+		r.say(m.AgentID, "", m.Text)
+
+	case "debate_summary":
+		var p struct {
+			SummarizedTurns int `json:"summarized_turns"`
+		}
+		_ = json.Unmarshal(data, &p)
+		r.printf("%s\n", r.paint(ansiDim, fmt.Sprintf("(turnos 1-%d resumidos para caber no contexto)", p.SummarizedTurns)))
+
+	case "vote":
+		var v domain.Vote
+		_ = json.Unmarshal(data, &v)
+		r.title("votes", "Votação")
+		r.printf("%s → %s\n%s\n", r.name(v.VoterID), r.name(v.TargetID), wrap(quote(v.Justification), lineWidth, "  "))
+
+	case "audience_tally":
+		var p struct {
+			Tally map[string]int `json:"tally"`
+			Final bool           `json:"final"`
+		}
+		_ = json.Unmarshal(data, &p)
+		if p.Final {
+			r.printf("📣 Público: %s\n", r.tally(p.Tally))
+		}
+
+	case "judge_vote":
+		var p struct {
+			TargetID      string   `json:"target_id"`
+			Justification string   `json:"justification"`
+			TiedAgents    []string `json:"tied_agents"`
+		}
+		_ = json.Unmarshal(data, &p)
+		r.title("judge", phaseTitles["judge"])
+		tied := make([]string, 0, len(p.TiedAgents))
+		for _, id := range p.TiedAgents {
+			tied = append(tied, r.name(id))
+		}
+		r.printf("⚖️  Empate entre %s; o juiz escolhe %s\n%s\n", strings.Join(tied, ", "), r.name(p.TargetID), wrap(quote(p.Justification), lineWidth, "  "))
+
+	case "finale_statement":
+		var s domain.FinaleStatement
+		_ = json.Unmarshal(data, &s)
+		r.say(s.AgentID, stageNames[s.Stage], s.Text)
+
+	case "finale_verdict":
+		var v domain.FinaleVerdict
+		_ = json.Unmarshal(data, &v)
+		r.printf("%s vota em %s\n%s\n", r.name(v.VoterID), r.name(v.TargetID), wrap(quote(v.Justification), lineWidth, "  "))
+
+	case "phase":
+		var p struct {
+			Phase string `json:"phase"`
+		}
+		_ = json.Unmarshal(data, &p)
+		if t, ok := phaseTitles[p.Phase]; ok {
+			r.title(p.Phase, t)
+		}
+
+	case "moderation":
+		var a domain.ModerationAction
+		_ = json.Unmarshal(data, &a)
+		r.printf("%s\n", r.paint(ansiDim, fmt.Sprintf("[moderação] %s de %s: %s (%s)", a.Field, stripANSI(r.name(a.AgentID)), a.Action, a.Reason)))
+
+	case "awaiting_input":
+		var p struct {
+			AgentID string `json:"agent_id"`
+			Phase   string `json:"phase"`
+			Timeout int    `json:"timeout_seconds"`
+		}
+		_ = json.Unmarshal(data, &p)
+		r.printf("⏳ À espera de %s (%s, %ds)\n", r.name(p.AgentID), p.Phase, p.Timeout)
+
+	case "input_timeout":
+		var p struct {
+			AgentID string `json:"agent_id"`
+			Phase   string `json:"phase"`
+		}
+		_ = json.Unmarshal(data, &p)
+		r.printf("%s\n", r.paint(ansiYellow, fmt.Sprintf("⌛ %s não respondeu a tempo (%s)", stripANSI(r.name(p.AgentID)), p.Phase)))
+
+	case "round_end":
+		var p struct {
+			Game  *domain.Game  `json:"game"`
+			Round *domain.Round `json:"round"`
+		}
+		_ = json.Unmarshal(data, &p)
+		if p.Game != nil {
+			r.game = p.Game
+		}
+		r.section = ""
+		if p.Round != nil {
+			for _, id := range p.Round.Eliminated {
+				r.printf("\n%s\n", r.paint(ansiRed, "💀 "+stripANSI(r.name(id))+" foi eliminado"))
+			}
+		}
+		r.status()
+
+	case "game_end":
+		var p struct {
+			WinnerID  string           `json:"winner_id"`
+			EndReason domain.EndReason `json:"end_reason"`
+			Game      *domain.Game     `json:"game"`
+		}
+		_ = json.Unmarshal(data, &p)
+		if p.Game != nil {
+			r.game = p.Game
+		}
+		r.gameEnd()
+
+	case "round_paused":
+		r.printf("\n%s\n", r.paint(ansiYellow, "⏸  Ronda em pausa (retoma com: hunger resume "+r.gameID()+")"))
+
+	case "round_resumed", "round_retried":
+		var p struct {
+			Round *domain.Round `json:"round"`
+		}
+		_ = json.Unmarshal(data, &p)
+		if p.Round == nil {
+			break
+		}
+		r.roundHeader(p.Round.Index, p.Round.Question)
+		verb := "retomada"
+		if name == "round_retried" {
+			verb = "repetida"
+		}
+		from := "do início"
+		if c := p.Round.Cursor; c != nil && c.Phase != "" {
+			phase := c.Phase
+			if t, ok := phaseTitles[phase]; ok {
+				phase = t
+			}
+			from = "a partir de: " + phase
+		}
+		r.printf("%s\n", r.paint(ansiDim, fmt.Sprintf("(ronda %s %s)", verb, from)))
+
+	case "round_cancelled":
+		r.printf("\n%s\n", r.paint(ansiYellow, "✖ Ronda cancelada"))
+
+	case "autoplay_end":
+		var p struct {
+			Reason       string `json:"reason"`
+			RoundsPlayed int    `json:"rounds_played"`
+		}
+		_ = json.Unmarshal(data, &p)
+		r.printf("\n%s\n", r.paint(ansiDim, fmt.Sprintf("autoplay terminou: %s (%d rondas)", p.Reason, p.RoundsPlayed)))
+
+	case "error":
+		var p struct {
+			Error string `json:"error"`
+		}
+		_ = json.Unmarshal(data, &p)
+		r.printf("\n%s\n", r.paint(ansiRed, "erro: "+p.Error))
+	}
+}
+
+func (r *renderer) gameID() string {
+	if r.game == nil {
+		return ""
+	}
+	return r.game.ID
+}
+
+func (r *renderer) roundHeader(index int, question string) {
+	r.section = ""
+	r.printf("\n%s\n", r.paint(ansiBold+ansiYellow, fmt.Sprintf("━━━ Ronda %d ━━━", index)))
+	if question != "" {
+		r.printf("%s %s\n", r.paint(ansiBold, "Pergunta:"), question)
+	}
+}
+
+func (r *renderer) tally(t map[string]int) string {
+	ids := make([]string, 0, len(t))
+	for id := range t {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if t[ids[i]] != t[ids[j]] {
+			return t[ids[i]] > t[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("%s %d", r.name(id), t[id]))
+	}
+	if len(parts) == 0 {
+		return "sem votos"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// status escreve o estado de cada agente: strikes, se está em jogo e o modelo.
+func (r *renderer) status() {
+	g := r.game
+	if g == nil {
+		return
+	}
+	r.printf("\n")
+	for _, a := range g.Agents {
+		strikes := strings.Repeat("●", min(a.Strikes, g.MaxStrikes)) + strings.Repeat("○", max(g.MaxStrikes-a.Strikes, 0))
+		state := r.paint(ansiGreen, "em jogo")
+		if a.Eliminated {
+			state = r.paint(ansiRed, fmt.Sprintf("eliminado na ronda %d", a.EliminatedRound))
+		}
+		who := a.Model
+		if a.Human {
+			who = "humano"
+		}
+		r.printf("  %-9s %s  %s  %s  %s\n", a.ID, pad(r.name(a.ID), a.Name, 22), strikes, state, r.paint(ansiDim, who))
+	}
+}
+
+func (r *renderer) gameEnd() {
+	g := r.game
+	if g == nil {
+		return
+	}
+	winner := "ninguém"
+	if g.WinnerID != "" {
+		winner = r.name(g.WinnerID)
+	}
+	r.printf("\n%s %s %s\n", r.paint(ansiBold+ansiYellow, "🏆 Vencedor:"), winner, r.paint(ansiDim, "("+string(g.EndReason)+")"))
+	for _, s := range g.Standings {
+		line := fmt.Sprintf("  %d. %s  %d strikes, %d votos", s.Placement, pad(r.name(s.AgentID), agentName(g, s.AgentID), 22), s.Strikes, s.VotesReceived)
+		if s.EliminatedRound > 0 {
+			line += fmt.Sprintf(", eliminado na ronda %d", s.EliminatedRound)
+		}
+		r.printf("%s\n", line)
+	}
+}
+
+func agentName(g *domain.Game, id string) string {
+	if a := g.Agent(id); a != nil {
+		return a.Name
+	}
+	return id
+}
+
+// pad alinha um texto com cor pela largura do texto sem cor.
+func pad(colored, plain string, width int) string {
+	if n := width - len([]rune(plain)); n > 0 {
+		return colored + strings.Repeat(" ", n)
+	}
+	return colored
+}
+
+func stripANSI(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\033' {
+			for i < len(s) && s[i] != 'm' {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func quote(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "“" + s + "”"
+}
+
+// wrap parte o texto em linhas de no máximo width caracteres, com indent no
+// início de cada uma. Mantém as quebras de linha do texto.
+func wrap(text string, width int, indent string) string {
+	var lines []string
+	for _, para := range strings.Split(strings.TrimSpace(text), "\n") {
+		line := indent
+		for _, word := range strings.Fields(para) {
+			if line != indent && len([]rune(line))+1+len([]rune(word)) > width {
+				lines = append(lines, line)
+				line = indent
+			}
+			if line != indent {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
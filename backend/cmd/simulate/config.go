@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+// config é o ficheiro da simulação. Os campos do jogo têm os mesmos nomes que
+// no POST /games.
+type config struct {
+	Games       int             `json:"games"`
+	Concurrency int             `json:"concurrency"`
+	Language    domain.Language `json:"language"`
+	PromptSet   string          `json:"prompt_set"`
+	Seed        int64           `json:"seed"` // 0 = cada jogo com uma semente aleatória
+
+	// Sistema de votação e estrutura das rondas
+	MaxStrikes    int                  `json:"max_strikes"`
+	FinaleDecider domain.FinaleDecider `json:"finale_decider"`
+	BlindVoting   bool                 `json:"blind_voting"`
+	SpeakingOrder domain.SpeakingOrder `json:"speaking_order"`
+	RoundConfig   domain.RoundConfig   `json:"round_config"`
+
+	Questions    questionsConfig `json:"questions"`
+	MaxRounds    int             `json:"max_rounds"`
+	RoundTimeout int             `json:"round_timeout_seconds"` // 0 = 180
+
+	Rosters []rosterConfig `json:"rosters"`
+}
+
+type questionsConfig struct {
+	Source usecase.QuestionSource `json:"source"` // list, bank ou generate
+	List   []string               `json:"list"`
+	File   string                 `json:"file"`   // perguntas importadas para o banco (JSON ou CSV)
+	Filter *domain.QuestionFilter `json:"filter"` // perguntas do banco usadas
+	Theme  string                 `json:"theme"`  // tema das perguntas geradas
+
+	ProvocativeHost bool `json:"provocative_host"`
+}
+
+type rosterConfig struct {
+	Name   string        `json:"name"`
+	Agents []agentConfig `json:"agents"`
+}
+
+type agentConfig struct {
+	Model   string          `json:"model"`   // vazio = GROQ_MODEL
+	Persona *domain.Persona `json:"persona"` // vazia = a persona embutida do lugar
+}
+
+// loadConfig lê YAML ou JSON. O YAML é convertido para JSON para os tipos do
+// domínio, que só têm tags json, serem lidos como na API.
+func loadConfig(path string) (*config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if data, err = json.Marshal(raw); err != nil {
+		return nil, err
+	}
+
+	cfg := &config{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(cfg); err != nil {
+		return nil, err
+	}
+
+	// O ficheiro de perguntas é relativo à configuração
+	if f := cfg.Questions.File; f != "" && !filepath.IsAbs(f) {
+		cfg.Questions.File = filepath.Join(filepath.Dir(path), f)
+	}
+	return cfg, nil
+}
+
+// input converte a configuração na entrada do SimulationUseCase.
+func (c *config) input() usecase.SimulationInput {
+	lang := c.Language
+	if lang == "" {
+		lang = domain.DefaultLanguage
+	}
+
+	rosters := make([]usecase.SimulationRoster, 0, len(c.Rosters))
+	for r, rc := range c.Rosters {
+		roster := usecase.SimulationRoster{Name: rc.Name}
+		if roster.Name == "" {
+			roster.Name = fmt.Sprintf("roster-%d", r+1)
+		}
+		for i, ac := range rc.Agents {
+			p := domain.DefaultPersona(lang, i)
+			if ac.Persona != nil {
+				p = ac.Persona
+			}
+			roster.Personas = append(roster.Personas, *p)
+			roster.Models = append(roster.Models, ac.Model)
+		}
+		rosters = append(rosters, roster)
+	}
+
+	timeout := 180 * time.Second
+	if c.RoundTimeout > 0 {
+		timeout = time.Duration(c.RoundTimeout) * time.Second
+	}
+
+	return usecase.SimulationInput{
+		Games:       c.Games,
+		Concurrency: c.Concurrency,
+		Rosters:     rosters,
+		Game: usecase.CreateGameInput{
+			MaxStrikes:      c.MaxStrikes,
+			FinaleDecider:   c.FinaleDecider,
+			Language:        lang,
+			PromptSet:       c.PromptSet,
+			Theme:           c.Questions.Theme,
+			ProvocativeHost: c.Questions.ProvocativeHost,
+			SpeakingOrder:   c.SpeakingOrder,
+			Seed:            c.Seed,
+			BlindVoting:     c.BlindVoting,
+			RoundConfig:     c.RoundConfig,
+			QuestionFilter:  c.Questions.Filter,
+		},
+		Source:       c.Questions.Source,
+		Questions:    c.Questions.List,
+		MaxRounds:    c.MaxRounds,
+		RoundTimeout: timeout,
+	}
+}
@@ -0,0 +1,156 @@
+// Comando simulate: joga muitos jogos completos sem HTTP e grava os
+// resultados de cada jogo e as estatísticas agregadas em JSON ou CSV.
+//
+//	go run ./cmd/simulate -config simulacao.yaml -out resultados -format csv
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+	"github.com/rafawastaken/ai-hunger-games/internal/service"
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+func main() {
+	configPath := flag.String("config", "simulation.yaml", "ficheiro de configuração (YAML ou JSON)")
+	outDir := flag.String("out", "simulation", "diretório onde os resultados são gravados")
+	format := flag.String("format", "json", "formato dos resultados: json ou csv")
+	games := flag.Int("games", 0, "número de jogos (substitui o da configuração)")
+	concurrency := flag.Int("concurrency", 0, "jogos em simultâneo (substitui o da configuração)")
+	flag.Parse()
+
+	if *format != "json" && *format != "csv" {
+		log.Fatalf("formato inválido: %s (usa json ou csv)", *format)
+	}
+
+	// Carregar .env (se existir)
+	_ = godotenv.Load()
+
+	apiKey := os.Getenv("GROQ_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("GROQ_API_KEY")
+	}
+	if apiKey == "" {
+		log.Fatal("GROQ_KEY ou GROQ_API_KEY não encontrados no ambiente/.env")
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("erro a ler %s: %v", *configPath, err)
+	}
+	if *games > 0 {
+		cfg.Games = *games
+	}
+	if *concurrency > 0 {
+		cfg.Concurrency = *concurrency
+	}
+
+	// Wiring de dependências, tudo em memória
+	gameRepo := repository.NewInMemoryGameRepository()
+	questionRepo := repository.NewInMemoryQuestionRepository()
+	prompts, err := service.LoadPromptLibrary(os.Getenv("PROMPTS_DIR"))
+	if err != nil {
+		log.Fatalf("erro a carregar os prompts: %v", err)
+	}
+
+	model := os.Getenv("GROQ_MODEL")
+	if model == "" {
+		model = service.DefaultModel
+	}
+	contextLimits, err := service.ParseContextLimits(os.Getenv("GROQ_CONTEXT_LIMIT"), model)
+	if err != nil {
+		log.Fatalf("GROQ_CONTEXT_LIMIT: %v", err)
+	}
+	groqSvc := service.NewGroqService(apiKey, model, contextLimits, prompts)
+
+	questionBankUC := usecase.NewQuestionBankUseCase(questionRepo)
+	if cfg.Questions.File != "" {
+		data, err := os.ReadFile(cfg.Questions.File)
+		if err != nil {
+			log.Fatalf("erro a ler as perguntas: %v", err)
+		}
+		imported, err := questionBankUC.Import(data, questionFormat(cfg.Questions.File))
+		if err != nil {
+			log.Fatalf("erro a importar as perguntas: %v", err)
+		}
+		log.Printf("%d perguntas importadas de %s", len(imported), cfg.Questions.File)
+	} else if _, err := questionBankUC.Seed(); err != nil {
+		log.Fatalf("erro a semear o banco de perguntas: %v", err)
+	}
+
+	createGameUC := usecase.NewCreateGameUseCase(gameRepo, repository.NewInMemoryPersonaRepository(), prompts, model)
+	playRoundUC := usecase.NewPlayRoundUseCase(gameRepo, groqSvc, questionBankUC, usecase.QuestionPolicy{}, usecase.ModerationPolicy{}, nil)
+	autoplayUC := usecase.NewAutoplayUseCase(gameRepo, playRoundUC)
+	simulationUC := usecase.NewSimulationUseCase(gameRepo, createGameUC, autoplayUC)
+
+	input := cfg.input()
+	start := time.Now()
+	done := 0
+	input.OnGame = func(res usecase.SimulationGameResult) {
+		done++
+		status := string(res.EndReason)
+		if res.Error != "" {
+			status = "erro: " + res.Error
+		} else if !res.Finished {
+			status = "por acabar: " + res.StopReason
+		}
+		log.Printf("[%d/%d] jogo %d (%s): %d rondas, %s", done, input.Games, res.Index, res.Roster, res.Rounds, status)
+	}
+
+	// Ctrl+C pára a simulação e grava os jogos que já acabaram
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	log.Printf("a simular %d jogos, %d de cada vez", input.Games, max(input.Concurrency, 1))
+	out, err := simulationUC.Execute(ctx, input)
+	if out == nil {
+		log.Fatalf("erro na simulação: %v", err)
+	}
+	if err != nil {
+		log.Printf("simulação interrompida: %v", err)
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatalf("erro a criar %s: %v", *outDir, err)
+	}
+	if *format == "csv" {
+		err = writeCSV(*outDir, out)
+	} else {
+		err = writeJSONFile(filepath.Join(*outDir, "results.json"), out)
+	}
+	if err != nil {
+		log.Fatalf("erro a gravar os resultados: %v", err)
+	}
+
+	log.Printf("%d jogos em %s (%d acabados, %d com erro), resultados em %s",
+		out.Stats.Games, time.Since(start).Round(time.Second), out.Stats.Finished, out.Stats.Failed, *outDir)
+	for _, m := range out.Stats.Models {
+		log.Printf("  %-40s vitórias %5.1f%%  sobrevivência %5.1f%%  (%d lugares)", m.Name, m.WinRate*100, m.AvgSurvival*100, m.Appearances)
+	}
+}
+
+func questionFormat(path string) usecase.QuestionFormat {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return usecase.QuestionFormatCSV
+	}
+	return usecase.QuestionFormatJSON
+}
+
+func writeJSONFile(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
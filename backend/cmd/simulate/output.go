@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+// writeCSV grava três ficheiros: games.csv (um jogo por linha), agents.csv
+// (um agente de um jogo por linha) e summary.csv (as estatísticas agregadas).
+func writeCSV(dir string, out *usecase.SimulationOutput) error {
+	games := [][]string{{"index", "game_id", "roster", "seed", "rounds", "ties", "finished", "stop_reason", "end_reason", "winner_id", "error"}}
+	agents := [][]string{{"game_index", "game_id", "roster", "agent_id", "persona", "model", "placement", "eliminated_round", "rounds_survived", "strikes", "votes_received", "winner"}}
+	for _, res := range out.Results {
+		games = append(games, []string{
+			strconv.Itoa(res.Index), res.GameID, res.Roster, strconv.FormatInt(res.Seed, 10),
+			strconv.Itoa(res.Rounds), strconv.Itoa(res.Ties), strconv.FormatBool(res.Finished),
+			res.StopReason, string(res.EndReason), res.WinnerID, res.Error,
+		})
+		for _, a := range res.Agents {
+			agents = append(agents, []string{
+				strconv.Itoa(res.Index), res.GameID, res.Roster, a.AgentID, a.Persona, a.Model,
+				strconv.Itoa(a.Placement), strconv.Itoa(a.EliminatedRound), strconv.Itoa(a.RoundsSurvived),
+				strconv.Itoa(a.Strikes), strconv.Itoa(a.VotesReceived), strconv.FormatBool(a.Winner),
+			})
+		}
+	}
+
+	// summary.csv: uma linha por modelo e por persona, e os totais
+	stats := out.Stats
+	summary := [][]string{{"group", "name", "appearances", "wins", "win_rate", "avg_survival", "avg_placement"}}
+	for _, g := range []struct {
+		name  string
+		stats []usecase.GroupStats
+	}{{"model", stats.Models}, {"persona", stats.Personas}} {
+		for _, s := range g.stats {
+			summary = append(summary, []string{
+				g.name, s.Name, strconv.Itoa(s.Appearances), strconv.Itoa(s.Wins),
+				formatFloat(s.WinRate), formatFloat(s.AvgSurvival), formatFloat(s.AvgPlacement),
+			})
+		}
+	}
+	// Os totais usam a coluna appearances para o valor
+	for _, total := range [][2]string{
+		{"games", strconv.Itoa(stats.Games)},
+		{"finished", strconv.Itoa(stats.Finished)},
+		{"failed", strconv.Itoa(stats.Failed)},
+		{"rounds", strconv.Itoa(stats.Rounds)},
+		{"tie_rate", formatFloat(stats.TieRate)},
+	} {
+		summary = append(summary, []string{"total", total[0], total[1], "", "", "", ""})
+	}
+
+	for name, rows := range map[string][][]string{"games.csv": games, "agents.csv": agents, "summary.csv": summary} {
+		if err := writeCSVFile(filepath.Join(dir, name), rows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSVFile(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BlindBallot é o boletim de um votante em votação cega: as respostas dos
+// outros agentes, baralhadas e sob pseudónimos ("Resposta A", "Resposta B", ...).
+// Fica guardado na ronda para os votos poderem ser auditados.
+type BlindBallot struct {
+	VoterID  string       `json:"voter_id"`
+	Entries  []BlindEntry `json:"entries"`            // pela ordem em que foram mostradas ao votante
+	Choice   string       `json:"choice,omitempty"`   // pseudónimo que o votante escolheu
+	Fallback bool         `json:"fallback,omitempty"` // a escolha era inválida e o voto foi para a primeira entrada do boletim
+}
+
+// BlindEntry liga um pseudónimo ao agente verdadeiro.
+type BlindEntry struct {
+	Label   string `json:"label"` // "A", "B", ...
+	AgentID string `json:"agent_id"`
+}
+
+// BlindBallot baralha as respostas da ronda para o votante, sem a dele. A ordem
+// depende de Game.Seed, da ronda e do votante, por isso cada votante vê uma ordem diferente.
+func (g *Game) BlindBallot(round *Round, voter *Agent) BlindBallot {
+	var ids []string
+	for _, a := range round.Answers {
+		if a.AgentID != voter.ID {
+			ids = append(ids, a.AgentID)
+		}
+	}
+
+	rng := g.rng("blind/"+voter.ID, round.Index)
+	rng.Shuffle(len(ids), func(i, j int) {
+		ids[i], ids[j] = ids[j], ids[i]
+	})
+
+	ballot := BlindBallot{VoterID: voter.ID}
+	for i, id := range ids {
+		ballot.Entries = append(ballot.Entries, BlindEntry{Label: blindLabel(i), AgentID: id})
+	}
+	return ballot
+}
+
+// Resolve devolve o agente por trás do pseudónimo escolhido. Aceita só a letra
+// ("B") ou o pseudónimo completo em qualquer língua ("Resposta B", "Answer B").
+func (b *BlindBallot) Resolve(choice string) (string, bool) {
+	fields := strings.Fields(choice)
+	if len(fields) == 0 {
+		return "", false
+	}
+	label := strings.ToUpper(strings.Trim(fields[len(fields)-1], `"'.:()[]`))
+	for _, e := range b.Entries {
+		if e.Label == label {
+			return e.AgentID, true
+		}
+	}
+	return "", false
+}
+
+// blindLabel devolve A..Z e, a partir daí, A1, B1, ...
+func blindLabel(i int) string {
+	if i < 26 {
+		return string(rune('A' + i))
+	}
+	return fmt.Sprintf("%c%d", 'A'+i%26, i/26)
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func blindRound(g *Game, index int) *Round {
+	r := &Round{Index: index}
+	for _, a := range g.Agents {
+		r.Answers = append(r.Answers, Answer{AgentID: a.ID, Text: "resposta de " + a.ID})
+	}
+	return r
+}
+
+func ballotIDs(b BlindBallot) []string {
+	ids := make([]string, 0, len(b.Entries))
+	for _, e := range b.Entries {
+		ids = append(ids, e.AgentID)
+	}
+	return ids
+}
+
+func TestBlindBallot(t *testing.T) {
+	g := newTestGame(6)
+	g.Seed = 42
+	voter := g.Agents[2]
+
+	b := g.BlindBallot(blindRound(g, 1), voter)
+	if b.VoterID != voter.ID || len(b.Entries) != 5 {
+		t.Fatalf("boletim = %+v", b)
+	}
+	for i, e := range b.Entries {
+		if e.AgentID == voter.ID {
+			t.Errorf("o votante aparece no próprio boletim")
+		}
+		if e.Label != blindLabel(i) {
+			t.Errorf("entrada %d: label %q, want %q", i, e.Label, blindLabel(i))
+		}
+	}
+
+	// A mesma semente, ronda e votante dão sempre o mesmo boletim
+	if again := g.BlindBallot(blindRound(g, 1), voter); !slices.Equal(ballotIDs(again), ballotIDs(b)) {
+		t.Errorf("boletim não reprodutível: %v vs %v", ballotIDs(again), ballotIDs(b))
+	}
+
+	// Votantes e rondas diferentes não veem sempre a mesma ordem
+	first := make(map[string]int)
+	for round := 1; round <= 10; round++ {
+		for _, v := range g.Agents {
+			first[g.BlindBallot(blindRound(g, round), v).Entries[0].AgentID]++
+		}
+	}
+	if len(first) < 4 {
+		t.Errorf("a primeira entrada quase não varia: %v", first)
+	}
+}
+
+func TestBlindBallotResolve(t *testing.T) {
+	b := BlindBallot{Entries: []BlindEntry{
+		{Label: "A", AgentID: "agent-3"},
+		{Label: "B", AgentID: "agent-1"},
+	}}
+
+	tests := []struct {
+		choice string
+		want   string
+		ok     bool
+	}{
+		{"B", "agent-1", true},
+		{"b", "agent-1", true},
+		{"Resposta A", "agent-3", true},
+		{"Answer B.", "agent-1", true},
+		{`"(A)"`, "agent-3", true},
+		{"C", "", false},
+		{"agent-1", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := b.Resolve(tt.choice)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Resolve(%q) = %q, %v, want %q, %v", tt.choice, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBlindLabel(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "A1", 27: "B1", 52: "A2"} {
+		if got := blindLabel(i); got != want {
+			t.Errorf("blindLabel(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
@@ -1,10 +1,16 @@
 package domain
 
+import "time"
+
 type Agent struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Strikes    int    `json:"strikes"`
-	Eliminated bool   `json:"eliminated"`
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Strikes         int      `json:"strikes"`
+	Eliminated      bool     `json:"eliminated"`
+	EliminatedRound int      `json:"eliminated_round,omitempty"`
+	Human           bool     `json:"human"`
+	Persona         *Persona `json:"persona,omitempty"`
+	Model           string   `json:"model,omitempty"` // modelo LLM do agente (vazio nos humanos)
 }
 
 type Answer struct {
@@ -24,13 +30,101 @@ type Vote struct {
 	Justification string `json:"justification"`
 }
 
+// JudgeDecision é a escolha do juiz quando os mais votados da ronda empatam.
+type JudgeDecision struct {
+	TiedAgents    []string `json:"tied_agents"`
+	TargetID      string   `json:"target_id"`
+	Justification string   `json:"justification"`
+}
+
+// Phase identifica uma fase da ronda em que os agentes têm de intervir.
+type Phase string
+
+const (
+	PhaseAnswer Phase = "answer"
+	PhaseDebate Phase = "debate"
+	PhaseVote   Phase = "vote"
+	PhaseFinale Phase = "finale"
+)
+
+// FinaleStage identifica cada fase da final entre os dois últimos agentes.
+type FinaleStage string
+
+const (
+	FinaleStageOpening          FinaleStage = "opening"
+	FinaleStageCrossExamination FinaleStage = "cross_examination"
+	FinaleStageClosing          FinaleStage = "closing"
+)
+
+// FinaleStages é a ordem em que as fases da final são jogadas.
+var FinaleStages = []FinaleStage{
+	FinaleStageOpening,
+	FinaleStageCrossExamination,
+	FinaleStageClosing,
+}
+
+// FinaleDecider define quem escolhe o vencedor da final.
+type FinaleDecider string
+
+const (
+	FinaleDeciderJudges   FinaleDecider = "judges"   // painel de juízes LLM
+	FinaleDeciderJury     FinaleDecider = "jury"     // agentes já eliminados
+	FinaleDeciderAudience FinaleDecider = "audience" // votos do público
+)
+
+type FinaleStatement struct {
+	AgentID string      `json:"agent_id"`
+	Stage   FinaleStage `json:"stage"`
+	Text    string      `json:"text"`
+}
+
+// FinaleVerdict é o voto de um juiz ou jurado no finalista que deve VENCER.
+type FinaleVerdict struct {
+	VoterID       string `json:"voter_id"`
+	TargetID      string `json:"target_id"`
+	Justification string `json:"justification"`
+	Tiebreak      bool   `json:"tiebreak,omitempty"` // juiz extra chamado porque a final estava empatada
+}
+
+type Finale struct {
+	Finalists  []string          `json:"finalists"`
+	Statements []FinaleStatement `json:"statements"`
+	Decider    FinaleDecider     `json:"decider"`
+	Verdicts   []FinaleVerdict   `json:"verdicts"`
+	Audience   *AudienceResult   `json:"audience,omitempty"`
+	WinnerID   string            `json:"winner_id"`
+}
+
+// AudienceResult guarda os votos do público numa ronda e como pesaram nos strikes.
+type AudienceResult struct {
+	Tally  map[string]int     `json:"tally"`
+	Weight float64            `json:"weight"`
+	Scores map[string]float64 `json:"scores,omitempty"` // votos dos agentes + peso do público
+}
+
 type Round struct {
-	Index      int             `json:"index"`
-	Question   string          `json:"question"`
-	Answers    []Answer        `json:"answers"`
-	Debate     []DebateMessage `json:"debate"`
-	Votes      []Vote          `json:"votes"`
-	Eliminated []string        `json:"eliminated"`
+	Index           int                `json:"index"`
+	Question        string             `json:"question"`
+	QuestionID      string             `json:"question_id,omitempty"`    // quando a pergunta veio do banco
+	AutoQuestion    bool               `json:"auto_question,omitempty"`  // pergunta gerada pelo apresentador
+	PromptVersion   string             `json:"prompt_version,omitempty"` // set e versão dos prompts, ex: "pt@1"
+	Config          RoundConfig        `json:"config"`                   // estrutura efetiva da ronda
+	SpeakingOrder   map[Phase][]string `json:"speaking_order,omitempty"` // IDs pela ordem em que intervieram em cada fase
+	Answers         []Answer           `json:"answers"`
+	Debate          []DebateMessage    `json:"debate"`
+	DebateSummary   string             `json:"debate_summary,omitempty"`   // resumo dos turnos 1..SummarizedTurns
+	SummarizedTurns int                `json:"summarized_turns,omitempty"` // turnos que só entram nos prompts pelo resumo
+	Votes           []Vote             `json:"votes"`
+	BlindBallots    []BlindBallot      `json:"blind_ballots,omitempty"` // pseudónimos usados na votação cega
+	StrikeID        string             `json:"strike_id,omitempty"`     // agente que levou o strike da ronda
+	Judge           *JudgeDecision     `json:"judge,omitempty"`         // desempate do juiz, se houve
+	Eliminated      []string           `json:"eliminated"`
+	Finale          *Finale            `json:"finale,omitempty"`
+	Audience        *AudienceResult    `json:"audience,omitempty"`
+	Moderation      []ModerationAction `json:"moderation,omitempty"` // textos alterados pela moderação
+	State           RoundState         `json:"state,omitempty"`      // só enquanto a ronda está em curso
+	Cursor          *RoundCursor       `json:"cursor,omitempty"`     // só enquanto a ronda está em curso
+	Error           string             `json:"error,omitempty"`      // erro que fez a ronda falhar
 }
 
 type GameStatus string
@@ -42,11 +136,29 @@ const (
 )
 
 type Game struct {
-	ID         string     `json:"id"`
-	Agents     []*Agent   `json:"agents"`
-	Rounds     []*Round   `json:"rounds"`
-	MaxStrikes int        `json:"max_strikes"`
-	Status     GameStatus `json:"status"`
+	ID              string          `json:"id"`
+	Agents          []*Agent        `json:"agents"`
+	Rounds          []*Round        `json:"rounds"`
+	CurrentRound    *Round          `json:"current_round,omitempty"` // ronda começada e ainda não terminada
+	MaxStrikes      int             `json:"max_strikes"`
+	FinaleDecider   FinaleDecider   `json:"finale_decider"`
+	HumanTimeout    int             `json:"human_timeout_seconds"`   // tempo máximo que um humano tem por fase
+	AudienceWindow  int             `json:"audience_window_seconds"` // janela de votação do público (0 = desligada)
+	AudienceWeight  float64         `json:"audience_weight"`         // peso do público face aos votos dos agentes
+	Language        Language        `json:"language"`
+	SpeakingOrder   SpeakingOrder   `json:"speaking_order,omitempty"`   // estratégia de ordem por fase
+	BlindVoting     bool            `json:"blind_voting"`               // os agentes votam em respostas anónimas e baralhadas
+	RoundConfig     RoundConfig     `json:"round_config"`               // estrutura das rondas, alterável por ronda
+	QuestionFilter  *QuestionFilter `json:"question_filter,omitempty"`  // perguntas do banco que o jogo usa
+	Theme           string          `json:"theme,omitempty"`            // tema das perguntas geradas pelo apresentador
+	ProvocativeHost bool            `json:"provocative_host,omitempty"` // o apresentador usa as personas para pôr os agentes em conflito
+	Seed            int64           `json:"seed"`                       // semente das ordens baralhadas
+	PromptSet       string          `json:"prompt_set,omitempty"`       // variante de prompts (vazio = por omissão)
+	Status          GameStatus      `json:"status"`
+	WinnerID        string          `json:"winner_id,omitempty"`
+	EndReason       EndReason       `json:"end_reason,omitempty"`
+	Standings       []Standing      `json:"standings,omitempty"`
+	FinishedAt      *time.Time      `json:"finished_at,omitempty"`
 }
 
 // Helpers
@@ -64,3 +176,43 @@ func (g *Game) ActiveAgents() []*Agent {
 func (g *Game) NextRoundIndex() int {
 	return len(g.Rounds) + 1
 }
+
+func (g *Game) Agent(id string) *Agent {
+	for _, a := range g.Agents {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// Eliminate marca o agente como eliminado na ronda indicada.
+func (g *Game) Eliminate(a *Agent, round *Round) {
+	a.Eliminated = true
+	a.EliminatedRound = round.Index
+	round.Eliminated = append(round.Eliminated, a.ID)
+}
+
+func (g *Game) HasHumans() bool {
+	for _, a := range g.Agents {
+		if a.Human {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) EliminatedAgents() []*Agent {
+	var res []*Agent
+	for _, a := range g.Agents {
+		if a.Eliminated {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// IsFinale indica se a próxima ronda é a final (restam exatamente 2 agentes).
+func (g *Game) IsFinale() bool {
+	return len(g.ActiveAgents()) == 2
+}
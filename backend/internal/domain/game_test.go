@@ -0,0 +1,55 @@
+package domain
+
+import "testing"
+
+func newTestGame(n int) *Game {
+	g := &Game{MaxStrikes: 2}
+	for i := 1; i <= n; i++ {
+		g.Agents = append(g.Agents, &Agent{ID: "agent-" + string(rune('0'+i))})
+	}
+	return g
+}
+
+func TestIsFinale(t *testing.T) {
+	tests := []struct {
+		agents     int
+		eliminated int
+		want       bool
+	}{
+		{agents: 4, eliminated: 0, want: false},
+		{agents: 4, eliminated: 1, want: false},
+		{agents: 4, eliminated: 2, want: true},
+		{agents: 4, eliminated: 3, want: false},
+		{agents: 2, eliminated: 0, want: true},
+	}
+	for _, tt := range tests {
+		g := newTestGame(tt.agents)
+		for i := 0; i < tt.eliminated; i++ {
+			g.Agents[i].Eliminated = true
+		}
+		if got := g.IsFinale(); got != tt.want {
+			t.Errorf("%d agentes, %d eliminados: IsFinale() = %v, want %v", tt.agents, tt.eliminated, got, tt.want)
+		}
+	}
+}
+
+func TestEliminate(t *testing.T) {
+	g := newTestGame(3)
+	round := &Round{Index: 4}
+
+	g.Eliminate(g.Agents[1], round)
+
+	a := g.Agents[1]
+	if !a.Eliminated || a.EliminatedRound != 4 {
+		t.Errorf("agente = %+v, want eliminado na ronda 4", a)
+	}
+	if len(round.Eliminated) != 1 || round.Eliminated[0] != a.ID {
+		t.Errorf("round.Eliminated = %v, want [%s]", round.Eliminated, a.ID)
+	}
+	if got := g.EliminatedAgents(); len(got) != 1 || got[0] != a {
+		t.Errorf("EliminatedAgents() = %v", got)
+	}
+	if got := len(g.ActiveAgents()); got != 2 {
+		t.Errorf("ActiveAgents() = %d agentes, want 2", got)
+	}
+}
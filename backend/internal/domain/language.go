@@ -0,0 +1,32 @@
+package domain
+
+// Language é a língua em que o jogo é jogado (prompts, textos de fallback e mensagens de erro).
+type Language string
+
+const (
+	LanguagePortuguese Language = "pt"
+	LanguageEnglish    Language = "en"
+)
+
+// DefaultLanguage é a língua dos jogos criados sem "language".
+const DefaultLanguage = LanguagePortuguese
+
+// Languages são as línguas suportadas.
+var Languages = []Language{LanguagePortuguese, LanguageEnglish}
+
+func (l Language) Valid() bool {
+	for _, lang := range Languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// Lang devolve a língua do jogo (DefaultLanguage nos jogos criados antes de haver línguas).
+func (g *Game) Lang() Language {
+	if g.Language == "" {
+		return DefaultLanguage
+	}
+	return g.Language
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"regexp"
+	"sort"
+)
+
+// PastPosition é o que um agente respondeu numa ronda anterior.
+type PastPosition struct {
+	Round    int
+	Question string
+	Answer   string
+}
+
+// PastVote é um voto de uma ronda anterior.
+type PastVote struct {
+	Round         int
+	VoterID       string
+	TargetID      string
+	Justification string
+}
+
+// Grudge resume o que um adversário fez contra o agente.
+type Grudge struct {
+	AgentID      string
+	VotesAgainst int
+	Attacks      int
+}
+
+// AgentMemory é o que um agente "lembra" das rondas já terminadas.
+type AgentMemory struct {
+	AgentID       string
+	VotesReceived []PastVote // votos contra o agente
+	VotesCast     []PastVote
+	Grudges       []Grudge // ordenados do maior para o menor rancor
+	Positions     []PastPosition
+}
+
+func (m AgentMemory) Empty() bool {
+	return len(m.VotesReceived) == 0 && len(m.VotesCast) == 0 && len(m.Grudges) == 0 && len(m.Positions) == 0
+}
+
+// MemoryFor constrói a memória de um agente a partir das rondas anteriores (g.Rounds).
+// Um ataque é uma mensagem de debate de outro agente que menciona o ID ou o nome do agente.
+func (g *Game) MemoryFor(agentID string) AgentMemory {
+	mem := AgentMemory{AgentID: agentID}
+
+	mentions := mentionPattern(g.Agent(agentID))
+	grudges := make(map[string]*Grudge)
+	grudge := func(id string) *Grudge {
+		if _, ok := grudges[id]; !ok {
+			grudges[id] = &Grudge{AgentID: id}
+		}
+		return grudges[id]
+	}
+
+	for _, r := range g.Rounds {
+		for _, a := range r.Answers {
+			if a.AgentID == agentID {
+				mem.Positions = append(mem.Positions, PastPosition{Round: r.Index, Question: r.Question, Answer: a.Text})
+			}
+		}
+		for _, d := range r.Debate {
+			if d.AgentID != agentID && mentions != nil && mentions.MatchString(d.Text) {
+				grudge(d.AgentID).Attacks++
+			}
+		}
+		for _, v := range r.Votes {
+			pv := PastVote{Round: r.Index, VoterID: v.VoterID, TargetID: v.TargetID, Justification: v.Justification}
+			switch agentID {
+			case v.TargetID:
+				mem.VotesReceived = append(mem.VotesReceived, pv)
+				grudge(v.VoterID).VotesAgainst++
+			case v.VoterID:
+				mem.VotesCast = append(mem.VotesCast, pv)
+			}
+		}
+	}
+
+	for _, gr := range grudges {
+		mem.Grudges = append(mem.Grudges, *gr)
+	}
+	sort.Slice(mem.Grudges, func(i, j int) bool {
+		a, b := mem.Grudges[i], mem.Grudges[j]
+		if a.VotesAgainst+a.Attacks != b.VotesAgainst+b.Attacks {
+			return a.VotesAgainst+a.Attacks > b.VotesAgainst+b.Attacks
+		}
+		return a.AgentID < b.AgentID
+	})
+
+	return mem
+}
+
+// mentionPattern apanha o ID ou o nome do agente como palavra inteira
+// (para "agent-1" não apanhar "agent-10").
+func mentionPattern(a *Agent) *regexp.Regexp {
+	if a == nil {
+		return nil
+	}
+	expr := `(?i)(^|[^\pL\pN-])(` + regexp.QuoteMeta(a.ID)
+	if a.Name != "" {
+		expr += `|` + regexp.QuoteMeta(a.Name)
+	}
+	expr += `)($|[^\pL\pN-])`
+	return regexp.MustCompile(expr)
+}
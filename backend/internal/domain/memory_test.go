@@ -0,0 +1,88 @@
+package domain
+
+import "testing"
+
+func TestMentionPattern(t *testing.T) {
+	re := mentionPattern(&Agent{ID: "agent-1", Name: "Zé"})
+
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"o agent-1 não percebe nada", true},
+		{"Agent-1, estás errado", true},
+		{"(agent-1)", true},
+		{"agent-1", true},
+		{"o Zé não percebe nada", true},
+		{"zé!", true},
+		{"o agent-10 não percebe nada", false},
+		{"o agent-12 e o agent-11", false},
+		{"xagent-1", false},
+		{"agent-1-b", false},
+		{"Zézinho", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := re.MatchString(tt.text); got != tt.want {
+			t.Errorf("%q: menção = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+
+	if mentionPattern(nil) != nil {
+		t.Error("mentionPattern(nil) devia ser nil")
+	}
+	// O nome entra como texto, não como expressão
+	if re := mentionPattern(&Agent{ID: "agent-2", Name: "A.I."}); re.MatchString("o ABIC") {
+		t.Error("o nome foi lido como expressão regular")
+	}
+}
+
+func TestMemoryFor(t *testing.T) {
+	g := newTestGame(3)
+	g.Agents[0].Name = "Ana"
+	g.Rounds = []*Round{
+		{
+			Index:    1,
+			Question: "Q1?",
+			Answers:  []Answer{{AgentID: "agent-1", Text: "sim"}, {AgentID: "agent-2", Text: "não"}},
+			Debate: []DebateMessage{
+				{AgentID: "agent-2", Text: "a Ana está errada"},
+				{AgentID: "agent-3", Text: "concordo com o agent-10"},
+				{AgentID: "agent-1", Text: "eu, agent-1, discordo"},
+			},
+			Votes: []Vote{
+				{VoterID: "agent-2", TargetID: "agent-1", Justification: "fraca"},
+				{VoterID: "agent-1", TargetID: "agent-3"},
+				{VoterID: "agent-3", TargetID: "agent-10"},
+			},
+		},
+		{
+			Index:    2,
+			Question: "Q2?",
+			Debate:   []DebateMessage{{AgentID: "agent-3", Text: "o agent-1 mudou de ideias"}},
+			Votes:    []Vote{{VoterID: "agent-2", TargetID: "agent-1"}},
+		},
+	}
+
+	mem := g.MemoryFor("agent-1")
+	if len(mem.Positions) != 1 || mem.Positions[0] != (PastPosition{Round: 1, Question: "Q1?", Answer: "sim"}) {
+		t.Errorf("Positions = %+v", mem.Positions)
+	}
+	if len(mem.VotesReceived) != 2 || len(mem.VotesCast) != 1 || mem.VotesCast[0].TargetID != "agent-3" {
+		t.Errorf("VotesReceived = %+v, VotesCast = %+v", mem.VotesReceived, mem.VotesCast)
+	}
+	// agent-2: 2 votos e 1 ataque; agent-3: 1 ataque (a menção ao agent-10 não conta)
+	want := []Grudge{{AgentID: "agent-2", VotesAgainst: 2, Attacks: 1}, {AgentID: "agent-3", Attacks: 1}}
+	if len(mem.Grudges) != len(want) {
+		t.Fatalf("Grudges = %+v, want %+v", mem.Grudges, want)
+	}
+	for i := range want {
+		if mem.Grudges[i] != want[i] {
+			t.Errorf("Grudges[%d] = %+v, want %+v", i, mem.Grudges[i], want[i])
+		}
+	}
+
+	if !g.MemoryFor("agent-5").Empty() {
+		t.Error("a memória de quem não jogou devia estar vazia")
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+// ModerationAction é o registo de uma intervenção da moderação num texto
+// gerado por um agente.
+type ModerationAction struct {
+	AgentID string `json:"agent_id"` // agente, ou "judge-N" nos veredictos dos juízes
+	Phase   Phase  `json:"phase"`
+	Field   string `json:"field"`  // text ou justification
+	Action  string `json:"action"` // redacted, regenerated ou removed
+	Reason  string `json:"reason"` // termos bloqueados ou motivo do classificador
+}
+
+// Ações da moderação.
+const (
+	ModerationRedacted    = "redacted"    // termos bloqueados tapados
+	ModerationRegenerated = "regenerated" // texto gerado outra vez
+	ModerationRemoved     = "removed"     // texto substituído por inteiro
+)
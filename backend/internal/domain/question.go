@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Difficulty é a dificuldade de uma pergunta do banco.
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+func (d Difficulty) Valid() bool {
+	switch d {
+	case DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return true
+	}
+	return false
+}
+
+// Question é uma pergunta do banco de perguntas.
+type Question struct {
+	ID         string     `json:"id"`
+	Text       string     `json:"text"`
+	Language   Language   `json:"language,omitempty"` // vazio = português
+	Category   string     `json:"category,omitempty"`
+	Tags       []string   `json:"tags,omitempty"`
+	Difficulty Difficulty `json:"difficulty,omitempty"`
+	TimesUsed  int        `json:"times_used"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// Lang devolve a língua da pergunta, com o default quando não está definida.
+func (q *Question) Lang() Language {
+	if q.Language == "" {
+		return DefaultLanguage
+	}
+	return q.Language
+}
+
+// QuestionFilter escolhe as perguntas do banco que um jogo pode usar
+// (ex: categoria "ética", sem repetir perguntas de outros jogos).
+type QuestionFilter struct {
+	Category   string     `json:"category,omitempty"`
+	Tags       []string   `json:"tags,omitempty"` // a pergunta tem de ter todas
+	Difficulty Difficulty `json:"difficulty,omitempty"`
+	NoRepeats  bool       `json:"no_repeats,omitempty"` // só perguntas nunca usadas noutros jogos
+}
+
+func (f *QuestionFilter) Validate() error {
+	if f.Difficulty != "" && !f.Difficulty.Valid() {
+		return fmt.Errorf("invalid difficulty: %s", f.Difficulty)
+	}
+	return nil
+}
+
+// Matches indica se a pergunta passa no filtro (um filtro nil deixa passar tudo).
+func (f *QuestionFilter) Matches(q *Question) bool {
+	if f == nil {
+		return true
+	}
+	if f.Category != "" && !strings.EqualFold(f.Category, q.Category) {
+		return false
+	}
+	if f.Difficulty != "" && f.Difficulty != q.Difficulty {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !slices.ContainsFunc(q.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			return false
+		}
+	}
+	return !f.NoRepeats || q.TimesUsed == 0
+}
+
+// AllowsQuestion indica se o jogo pode usar a pergunta do banco: tem de estar
+// na língua do jogo e passar no QuestionFilter.
+func (g *Game) AllowsQuestion(q *Question) bool {
+	return q.Lang() == g.Lang() && g.QuestionFilter.Matches(q)
+}
+
+// PickQuestion escolhe a pergunta da próxima ronda entre as do banco: na
+// língua do jogo, que passem no QuestionFilter e que ainda não tenham sido
+// feitas neste jogo. Entre essas prefere as menos usadas, e desempata com o
+// Seed para a escolha ser reprodutível. Devolve nil se não houver nenhuma.
+func (g *Game) PickQuestion(questions []*Question) *Question {
+	asked := make(map[string]bool, len(g.Rounds))
+	for _, r := range g.Rounds {
+		asked[r.Question] = true
+		if r.QuestionID != "" {
+			asked[r.QuestionID] = true
+		}
+	}
+
+	var candidates []*Question
+	for _, q := range questions {
+		if !g.AllowsQuestion(q) || asked[q.ID] || asked[q.Text] {
+			continue
+		}
+		switch {
+		case len(candidates) == 0 || q.TimesUsed < candidates[0].TimesUsed:
+			candidates = []*Question{q}
+		case q.TimesUsed == candidates[0].TimesUsed:
+			candidates = append(candidates, q)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[g.rng("questions", g.NextRoundIndex()).IntN(len(candidates))]
+}
@@ -0,0 +1,62 @@
+package domain
+
+// defaultQuestions são as perguntas embutidas de cada língua, com que o banco
+// de perguntas começa (ver DefaultQuestions).
+var defaultQuestions = map[Language][]string{
+	LanguagePortuguese: {
+		"A inteligência artificial vai criar mais empregos do que destrói?",
+		"Deve ser proibido usar o telemóvel nas escolas?",
+		"É mais importante ser feliz ou ser bem-sucedido?",
+		"O trabalho remoto é melhor do que o trabalho no escritório?",
+		"Devíamos colonizar Marte antes de resolver os problemas da Terra?",
+		"As redes sociais fazem mais mal do que bem?",
+		"O ensino universitário devia ser gratuito para todos?",
+		"É aceitável mentir para proteger os sentimentos de alguém?",
+		"Os carros deviam ser proibidos no centro das cidades?",
+		"A semana de trabalho devia ter só quatro dias?",
+		"Comer carne vai ser visto como imoral daqui a 50 anos?",
+		"Os videojogos são uma forma de arte?",
+		"Devia existir um rendimento básico universal?",
+		"É melhor viver na cidade ou no campo?",
+		"Os influenciadores digitais deviam ser regulados como publicidade?",
+		"O voto devia ser obrigatório?",
+		"A privacidade ainda existe na era da internet?",
+		"Os robôs deviam ter direitos?",
+		"É preferível ser temido ou ser amado?",
+		"O turismo em massa está a destruir as cidades?",
+	},
+	LanguageEnglish: {
+		"Will artificial intelligence create more jobs than it destroys?",
+		"Should phones be banned in schools?",
+		"Is it more important to be happy or to be successful?",
+		"Is remote work better than working at the office?",
+		"Should we colonise Mars before fixing the problems on Earth?",
+		"Do social networks do more harm than good?",
+		"Should university be free for everyone?",
+		"Is it acceptable to lie to protect someone's feelings?",
+		"Should cars be banned from city centres?",
+		"Should the working week be only four days long?",
+		"Will eating meat be seen as immoral 50 years from now?",
+		"Are video games an art form?",
+		"Should there be a universal basic income?",
+		"Is it better to live in the city or in the countryside?",
+		"Should influencers be regulated like advertising?",
+		"Should voting be mandatory?",
+		"Does privacy still exist in the internet age?",
+		"Should robots have rights?",
+		"Is it better to be feared or to be loved?",
+		"Is mass tourism destroying cities?",
+	},
+}
+
+// DefaultQuestions devolve as perguntas embutidas de todas as línguas, para
+// semear o banco de perguntas quando está vazio.
+func DefaultQuestions() []Question {
+	var res []Question
+	for _, lang := range Languages {
+		for _, text := range defaultQuestions[lang] {
+			res = append(res, Question{Text: text, Language: lang})
+		}
+	}
+	return res
+}
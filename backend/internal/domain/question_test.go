@@ -0,0 +1,72 @@
+package domain
+
+import "testing"
+
+func TestAllowsQuestion(t *testing.T) {
+	tests := []struct {
+		name   string
+		lang   Language
+		filter *QuestionFilter
+		q      Question
+		want   bool
+	}{
+		{"sem língua é português", LanguagePortuguese, nil, Question{}, true},
+		{"outra língua", LanguagePortuguese, nil, Question{Language: LanguageEnglish}, false},
+		{"categoria certa", LanguagePortuguese, &QuestionFilter{Category: "Ética"}, Question{Category: "ética"}, true},
+		{"categoria errada", LanguagePortuguese, &QuestionFilter{Category: "ética"}, Question{Category: "ciência"}, false},
+		{"falta uma tag", LanguageEnglish, &QuestionFilter{Tags: []string{"a", "b"}}, Question{Language: LanguageEnglish, Tags: []string{"A"}}, false},
+		{"já usada", LanguagePortuguese, &QuestionFilter{NoRepeats: true}, Question{TimesUsed: 1}, false},
+	}
+	for _, tt := range tests {
+		g := &Game{Language: tt.lang, QuestionFilter: tt.filter}
+		if got := g.AllowsQuestion(&tt.q); got != tt.want {
+			t.Errorf("%s: AllowsQuestion() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPickQuestion(t *testing.T) {
+	questions := []*Question{
+		{ID: "a", Text: "A?", TimesUsed: 2},
+		{ID: "b", Text: "B?", TimesUsed: 1},
+		{ID: "c", Text: "C?", TimesUsed: 1},
+		{ID: "d", Text: "D?", Language: LanguageEnglish},
+		{ID: "e", Text: "E?", Category: "ética", TimesUsed: 5},
+	}
+	ids := func(qs ...*Question) map[string]bool {
+		m := make(map[string]bool)
+		for _, q := range qs {
+			m[q.ID] = true
+		}
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		game   *Game
+		wantIn map[string]bool // nil = nenhuma pergunta
+	}{
+		{"as menos usadas na língua do jogo", &Game{Seed: 1}, ids(questions[1], questions[2])},
+		{"sem repetir as do jogo", &Game{Seed: 1, Rounds: []*Round{{QuestionID: "b"}, {Question: "C?"}}}, ids(questions[0])},
+		{"com filtro", &Game{Seed: 1, QuestionFilter: &QuestionFilter{Category: "ética"}}, ids(questions[4])},
+		{"em inglês", &Game{Seed: 1, Language: LanguageEnglish}, ids(questions[3])},
+		{"já foram todas", &Game{Seed: 1, QuestionFilter: &QuestionFilter{Category: "ética"}, Rounds: []*Round{{QuestionID: "e"}}}, nil},
+	}
+	for _, tt := range tests {
+		got := tt.game.PickQuestion(questions)
+		switch {
+		case tt.wantIn == nil && got != nil:
+			t.Errorf("%s: PickQuestion() = %s, want nil", tt.name, got.ID)
+		case tt.wantIn != nil && (got == nil || !tt.wantIn[got.ID]):
+			t.Errorf("%s: PickQuestion() = %v, want one of %v", tt.name, got, tt.wantIn)
+		}
+	}
+
+	// A mesma semente escolhe sempre a mesma
+	first := (&Game{Seed: 42}).PickQuestion(questions)
+	for i := 0; i < 10; i++ {
+		if got := (&Game{Seed: 42}).PickQuestion(questions); got != first {
+			t.Fatalf("PickQuestion() não é reprodutível: %s e %s", first.ID, got.ID)
+		}
+	}
+}
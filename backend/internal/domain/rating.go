@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"math"
+	"strings"
+)
+
+// Elo dos pares (modelo, persona).
+const (
+	DefaultRating = 1500.0
+	RatingK       = 32.0 // variação máxima num jogo, repartida pelos adversários
+)
+
+// Rating é a classificação Elo de uma persona jogada por um modelo.
+type Rating struct {
+	Model        string  `json:"model"`
+	Persona      string  `json:"persona"`              // nome da persona
+	PersonaID    string  `json:"persona_id,omitempty"` // quando vem da biblioteca
+	Rating       float64 `json:"rating"`
+	Games        int     `json:"games"`
+	Wins         int     `json:"wins"`
+	AvgPlacement float64 `json:"avg_placement"`
+}
+
+// RatingTable guarda as classificações e os jogos já contados, para cada jogo
+// contar uma só vez.
+type RatingTable struct {
+	Ratings    map[string]*Rating `json:"ratings"`     // RatingKey -> classificação
+	RatedGames map[string]bool    `json:"rated_games"` // IDs dos jogos já contados
+}
+
+func NewRatingTable() *RatingTable {
+	return &RatingTable{
+		Ratings:    make(map[string]*Rating),
+		RatedGames: make(map[string]bool),
+	}
+}
+
+// RatingKey identifica o par (modelo, persona) de um agente. As personas da
+// biblioteca contam pelo ID, as outras pelo nome.
+func RatingKey(a *Agent) string {
+	persona := a.Name
+	if a.Persona != nil {
+		persona = a.Persona.Name
+		if a.Persona.ID != "" {
+			persona = "id:" + a.Persona.ID
+		}
+	}
+	return a.Model + "|" + persona
+}
+
+// Rate atualiza as classificações com o resultado de um jogo terminado. Cada
+// par de agentes IA conta como um duelo: ganha quem ficou à frente na
+// classificação e empatam os que foram eliminados na mesma ronda. Devolve
+// false se o jogo não conta (já contado, por acabar ou com menos de 2 IAs).
+func (t *RatingTable) Rate(game *Game) bool {
+	if t.RatedGames[game.ID] || game.Status != GameStatusFinished {
+		return false
+	}
+
+	type entry struct {
+		agent     *Agent
+		placement int
+		rating    *Rating
+	}
+	var entries []entry
+	for _, s := range game.Standings {
+		a := game.Agent(s.AgentID)
+		if a == nil || a.Human {
+			continue
+		}
+		key := RatingKey(a)
+		r, ok := t.Ratings[key]
+		if !ok {
+			r = &Rating{Model: a.Model, Persona: a.Name, Rating: DefaultRating}
+			if a.Persona != nil {
+				r.Persona = a.Persona.Name
+				r.PersonaID = a.Persona.ID
+			}
+			t.Ratings[key] = r
+		}
+		entries = append(entries, entry{agent: a, placement: s.Placement, rating: r})
+	}
+	if len(entries) < 2 {
+		return false
+	}
+
+	// Todas as variações são calculadas com as classificações de antes do jogo
+	k := RatingK / float64(len(entries)-1)
+	deltas := make([]float64, len(entries))
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			a, b := entries[i], entries[j]
+			score := 0.5
+			sameRound := a.agent.Eliminated && b.agent.Eliminated && a.agent.EliminatedRound == b.agent.EliminatedRound
+			if !sameRound && a.placement < b.placement {
+				score = 1
+			} else if !sameRound && a.placement > b.placement {
+				score = 0
+			}
+			expected := 1 / (1 + math.Pow(10, (b.rating.Rating-a.rating.Rating)/400))
+			deltas[i] += k * (score - expected)
+			deltas[j] -= k * (score - expected)
+		}
+	}
+
+	for i, e := range entries {
+		r := e.rating
+		r.Rating += deltas[i]
+		r.AvgPlacement = (r.AvgPlacement*float64(r.Games) + float64(e.placement)) / float64(r.Games+1)
+		r.Games++
+		if e.agent.ID == game.WinnerID {
+			r.Wins++
+		}
+	}
+	t.RatedGames[game.ID] = true
+	return true
+}
+
+// RatingFilter escolhe as classificações mostradas no leaderboard.
+type RatingFilter struct {
+	Model    string // modelo exato
+	Persona  string // parte do nome da persona, sem distinguir maiúsculas
+	MinGames int
+}
+
+func (f RatingFilter) Matches(r *Rating) bool {
+	if f.Model != "" && r.Model != f.Model {
+		return false
+	}
+	if f.Persona != "" && !strings.Contains(strings.ToLower(r.Persona), strings.ToLower(f.Persona)) {
+		return false
+	}
+	return r.Games >= f.MinGames
+}
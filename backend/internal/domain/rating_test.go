@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+// newRatedGame devolve um jogo terminado com os agentes por esta ordem na
+// classificação. eliminated[i] é a ronda em que o agente i saiu (0 = venceu).
+func newRatedGame(id string, eliminated ...int) *Game {
+	g := newTestGame(len(eliminated))
+	g.ID = id
+	g.Status = GameStatusFinished
+	for i, a := range g.Agents {
+		a.Model = "m"
+		a.Name = a.ID
+		if eliminated[i] == 0 {
+			g.WinnerID = a.ID
+		} else {
+			a.Eliminated = true
+			a.EliminatedRound = eliminated[i]
+		}
+		// Agentes eliminados na mesma ronda ficam com o mesmo lugar
+		placement := i + 1
+		if i > 0 && eliminated[i] == eliminated[i-1] {
+			placement = g.Standings[i-1].Placement
+		}
+		g.Standings = append(g.Standings, Standing{AgentID: a.ID, Placement: placement, EliminatedRound: eliminated[i]})
+	}
+	return g
+}
+
+func TestRatingTableRate(t *testing.T) {
+	approx := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	t.Run("duelo", func(t *testing.T) {
+		table := NewRatingTable()
+		if !table.Rate(newRatedGame("g1", 0, 1)) {
+			t.Fatal("Rate() = false, want true")
+		}
+		winner, loser := table.Ratings["m|agent-1"], table.Ratings["m|agent-2"]
+		if !approx(winner.Rating, DefaultRating+RatingK/2) || !approx(loser.Rating, DefaultRating-RatingK/2) {
+			t.Errorf("ratings = %v / %v, want %v / %v", winner.Rating, loser.Rating, DefaultRating+RatingK/2, DefaultRating-RatingK/2)
+		}
+		if winner.Wins != 1 || loser.Wins != 0 || winner.Games != 1 || loser.AvgPlacement != 2 {
+			t.Errorf("estatísticas erradas: %+v / %+v", winner, loser)
+		}
+	})
+
+	t.Run("eliminados na mesma ronda empatam", func(t *testing.T) {
+		table := NewRatingTable()
+		table.Rate(newRatedGame("g1", 0, 2, 2))
+		second, third := table.Ratings["m|agent-2"], table.Ratings["m|agent-3"]
+		if !approx(second.Rating, third.Rating) {
+			t.Errorf("ratings = %v / %v, want equal", second.Rating, third.Rating)
+		}
+		var sum float64
+		for _, r := range table.Ratings {
+			sum += r.Rating
+		}
+		if !approx(sum, 3*DefaultRating) {
+			t.Errorf("soma dos ratings = %v, want %v", sum, 3*DefaultRating)
+		}
+	})
+
+	t.Run("o favorito ganha menos", func(t *testing.T) {
+		table := NewRatingTable()
+		table.Ratings["m|agent-1"] = &Rating{Model: "m", Persona: "agent-1", Rating: 1700}
+		table.Rate(newRatedGame("g1", 0, 1))
+		if gain := table.Ratings["m|agent-1"].Rating - 1700; gain <= 0 || gain >= RatingK/2 {
+			t.Errorf("ganho do favorito = %v, want entre 0 e %v", gain, RatingK/2)
+		}
+	})
+
+	t.Run("jogos que não contam", func(t *testing.T) {
+		table := NewRatingTable()
+		game := newRatedGame("g1", 0, 1)
+		table.Rate(game)
+		if table.Rate(game) {
+			t.Error("o mesmo jogo contou duas vezes")
+		}
+		running := newRatedGame("g2", 0, 1)
+		running.Status = GameStatusRunning
+		if table.Rate(running) {
+			t.Error("um jogo por acabar contou")
+		}
+		human := newRatedGame("g3", 0, 1)
+		human.Agents[1].Human = true
+		if table.Rate(human) {
+			t.Error("um jogo com só uma IA contou")
+		}
+		if r := table.Ratings["m|agent-1"]; r.Games != 1 {
+			t.Errorf("Games = %d, want 1", r.Games)
+		}
+	})
+}
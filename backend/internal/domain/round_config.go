@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"time"
+)
+
+// Valores por omissão da estrutura das rondas.
+const (
+	DefaultDebateTurns = 2
+	DefaultTemperature = 0.8 // mais criatividade e variação nas respostas
+	maxDebateTurns     = 10
+)
+
+// RoundConfig define a estrutura das rondas. O jogo tem a sua (Game.RoundConfig)
+// e cada ronda pode alterá-la só para si. A configuração efetiva fica na ronda.
+type RoundConfig struct {
+	Debate         *bool             `json:"debate,omitempty"`           // false = sem debate (default true)
+	DebateTurns    int               `json:"debate_turns,omitempty"`     // turnos de debate (default 2)
+	MaxAnswerWords int               `json:"max_answer_words,omitempty"` // limite das respostas iniciais (0 = o prompt pede 2-4 frases)
+	MaxDebateWords int               `json:"max_debate_words,omitempty"` // limite das mensagens do debate (0 = o prompt pede 2-3 frases)
+	MaxFinaleWords int               `json:"max_finale_words,omitempty"` // limite dos discursos da final (0 = o prompt pede 3-4 frases)
+	Temperature    map[Phase]float64 `json:"temperature,omitempty"`      // temperatura do LLM por fase (default 0.8)
+	Timeouts       map[Phase]int     `json:"timeout_seconds,omitempty"`  // segundos por intervenção em cada fase (0 = sem limite)
+}
+
+// Validate garante que os valores fazem sentido. Campos a zero são aceites (usam o default).
+func (c RoundConfig) Validate() error {
+	if c.DebateTurns < 0 || c.DebateTurns > maxDebateTurns {
+		return fmt.Errorf("debate_turns must be between 0 and %d (0 = default)", maxDebateTurns)
+	}
+	if c.MaxAnswerWords < 0 {
+		return fmt.Errorf("max_answer_words must be positive")
+	}
+	if c.MaxDebateWords < 0 {
+		return fmt.Errorf("max_debate_words must be positive")
+	}
+	if c.MaxFinaleWords < 0 {
+		return fmt.Errorf("max_finale_words must be positive")
+	}
+	for phase, t := range c.Temperature {
+		if err := validConfigPhase(phase); err != nil {
+			return fmt.Errorf("temperature: %w", err)
+		}
+		if t < 0 || t > 2 {
+			return fmt.Errorf("temperature for %s must be between 0 and 2", phase)
+		}
+	}
+	for phase, s := range c.Timeouts {
+		if err := validConfigPhase(phase); err != nil {
+			return fmt.Errorf("timeout_seconds: %w", err)
+		}
+		if s < 0 {
+			return fmt.Errorf("timeout_seconds for %s must be positive", phase)
+		}
+	}
+	return nil
+}
+
+func validConfigPhase(phase Phase) error {
+	switch phase {
+	case PhaseAnswer, PhaseDebate, PhaseVote, PhaseFinale:
+		return nil
+	}
+	return fmt.Errorf("invalid phase: %s", phase)
+}
+
+// Merge aplica por cima os campos definidos em o (os mapas são juntos fase a fase).
+func (c RoundConfig) Merge(o *RoundConfig) RoundConfig {
+	res := c
+	res.Temperature = maps.Clone(c.Temperature)
+	res.Timeouts = maps.Clone(c.Timeouts)
+	if o == nil {
+		return res
+	}
+
+	if o.Debate != nil {
+		debate := *o.Debate
+		res.Debate = &debate
+	}
+	if o.DebateTurns > 0 {
+		res.DebateTurns = o.DebateTurns
+	}
+	if o.MaxAnswerWords > 0 {
+		res.MaxAnswerWords = o.MaxAnswerWords
+	}
+	if o.MaxDebateWords > 0 {
+		res.MaxDebateWords = o.MaxDebateWords
+	}
+	if o.MaxFinaleWords > 0 {
+		res.MaxFinaleWords = o.MaxFinaleWords
+	}
+	for phase, t := range o.Temperature {
+		if res.Temperature == nil {
+			res.Temperature = make(map[Phase]float64)
+		}
+		res.Temperature[phase] = t
+	}
+	for phase, s := range o.Timeouts {
+		if res.Timeouts == nil {
+			res.Timeouts = make(map[Phase]int)
+		}
+		res.Timeouts[phase] = s
+	}
+	return res
+}
+
+// Turns devolve o número de turnos de debate (0 se o debate estiver desligado).
+func (c RoundConfig) Turns() int {
+	if c.Debate != nil && !*c.Debate {
+		return 0
+	}
+	if c.DebateTurns <= 0 {
+		return DefaultDebateTurns
+	}
+	return c.DebateTurns
+}
+
+func (c RoundConfig) TemperatureFor(phase Phase) float64 {
+	if t, ok := c.Temperature[phase]; ok {
+		return t
+	}
+	return DefaultTemperature
+}
+
+// Timeout devolve o tempo máximo por intervenção na fase (0 = sem limite).
+func (c RoundConfig) Timeout(phase Phase) time.Duration {
+	return time.Duration(c.Timeouts[phase]) * time.Second
+}
+
+// HasTimeouts indica se alguma fase tem tempo máximo.
+func (c RoundConfig) HasTimeouts() bool {
+	for _, s := range c.Timeouts {
+		if s > 0 {
+			return true
+		}
+	}
+	return false
+}
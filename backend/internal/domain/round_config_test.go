@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     RoundConfig
+		wantErr bool
+	}{
+		{"vazia", RoundConfig{}, false},
+		{"turnos no limite", RoundConfig{DebateTurns: maxDebateTurns}, false},
+		{"turnos a mais", RoundConfig{DebateTurns: maxDebateTurns + 1}, true},
+		{"turnos negativos", RoundConfig{DebateTurns: -1}, true},
+		{"palavras negativas", RoundConfig{MaxAnswerWords: -5}, true},
+		{"palavras do debate negativas", RoundConfig{MaxDebateWords: -1}, true},
+		{"palavras da final negativas", RoundConfig{MaxFinaleWords: -1}, true},
+		{"limites de palavras", RoundConfig{MaxAnswerWords: 60, MaxDebateWords: 40, MaxFinaleWords: 80}, false},
+		{"temperatura", RoundConfig{Temperature: map[Phase]float64{PhaseVote: 0.2}}, false},
+		{"temperatura alta", RoundConfig{Temperature: map[Phase]float64{PhaseVote: 2.5}}, true},
+		{"fase inválida", RoundConfig{Timeouts: map[Phase]int{"lunch": 10}}, true},
+		{"timeout negativo", RoundConfig{Timeouts: map[Phase]int{PhaseAnswer: -1}}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRoundConfigMerge(t *testing.T) {
+	off := false
+	game := RoundConfig{
+		DebateTurns: 3,
+		Temperature: map[Phase]float64{PhaseAnswer: 1},
+		Timeouts:    map[Phase]int{PhaseDebate: 20},
+	}
+
+	got := game.Merge(&RoundConfig{Debate: &off, Temperature: map[Phase]float64{PhaseVote: 0.3}})
+	if got.Turns() != 0 {
+		t.Errorf("Turns() = %d com o debate desligado", got.Turns())
+	}
+	if got.TemperatureFor(PhaseAnswer) != 1 || got.TemperatureFor(PhaseVote) != 0.3 || got.TemperatureFor(PhaseDebate) != DefaultTemperature {
+		t.Errorf("temperaturas = %v", got.Temperature)
+	}
+	if got.Timeout(PhaseDebate) != 20*time.Second {
+		t.Errorf("Timeout(debate) = %v", got.Timeout(PhaseDebate))
+	}
+	// A configuração do jogo não pode ser alterada pela da ronda
+	if _, ok := game.Temperature[PhaseVote]; ok || game.Debate != nil {
+		t.Errorf("Merge alterou a configuração do jogo: %+v", game)
+	}
+
+	words := RoundConfig{MaxDebateWords: 40}.Merge(&RoundConfig{MaxFinaleWords: 80})
+	if words.MaxDebateWords != 40 || words.MaxFinaleWords != 80 {
+		t.Errorf("limites de palavras = %d/%d, want 40/80", words.MaxDebateWords, words.MaxFinaleWords)
+	}
+
+	if turns := game.Merge(nil).Turns(); turns != 3 {
+		t.Errorf("Turns() sem override = %d, want 3", turns)
+	}
+	if turns := (RoundConfig{}).Turns(); turns != DefaultDebateTurns {
+		t.Errorf("Turns() por omissão = %d, want %d", turns, DefaultDebateTurns)
+	}
+}
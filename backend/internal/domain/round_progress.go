@@ -0,0 +1,17 @@
+package domain
+
+// RoundState é o estado da ronda em curso (Game.CurrentRound).
+type RoundState string
+
+const (
+	RoundStateRunning RoundState = "running"
+	RoundStatePaused  RoundState = "paused"
+	RoundStateFailed  RoundState = "failed" // parou num erro; pode ser repetida a partir do passo que falhou
+)
+
+// RoundCursor indica até onde a ronda em curso já foi jogada. Ao retomar, os
+// passos antes do cursor não são repetidos.
+type RoundCursor struct {
+	Step  int    `json:"step"`  // passos já concluídos
+	Phase string `json:"phase"` // fase do último passo começado
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+)
+
+// OrderStrategy define a ordem pela qual os agentes intervêm numa fase.
+type OrderStrategy string
+
+const (
+	OrderFixed           OrderStrategy = "fixed"            // ordem de criação
+	OrderShuffle         OrderStrategy = "shuffle"          // baralhada por ronda, reprodutível com Game.Seed
+	OrderRotate          OrderStrategy = "rotate"           // round-robin: quem começa avança um lugar por ronda
+	OrderReverseStanding OrderStrategy = "reverse_standing" // quem está pior na classificação fala primeiro
+)
+
+func (s OrderStrategy) Valid() bool {
+	switch s {
+	case OrderFixed, OrderShuffle, OrderRotate, OrderReverseStanding:
+		return true
+	}
+	return false
+}
+
+// SpeakingOrder guarda a estratégia de cada fase. Fases sem estratégia usam OrderFixed.
+type SpeakingOrder map[Phase]OrderStrategy
+
+// Validate garante que só há fases e estratégias conhecidas.
+func (o SpeakingOrder) Validate() error {
+	for phase, strategy := range o {
+		switch phase {
+		case PhaseAnswer, PhaseDebate, PhaseVote, PhaseFinale:
+		default:
+			return fmt.Errorf("invalid speaking_order phase: %s", phase)
+		}
+		if !strategy.Valid() {
+			return fmt.Errorf("invalid speaking_order strategy for %s: %s", phase, strategy)
+		}
+	}
+	return nil
+}
+
+// OrderAgents devolve os agentes pela ordem em que intervêm na fase da ronda.
+func (g *Game) OrderAgents(phase Phase, roundIndex int, agents []*Agent) []*Agent {
+	ordered := append([]*Agent(nil), agents...)
+	if len(ordered) < 2 {
+		return ordered
+	}
+
+	switch g.SpeakingOrder[phase] {
+	case OrderShuffle:
+		// Cada fase tem a sua semente para não baralharem todas da mesma maneira
+		rng := g.rng(string(phase), roundIndex)
+		rng.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+	case OrderRotate:
+		shift := (roundIndex - 1) % len(ordered)
+		ordered = append(ordered[shift:], ordered[:shift]...)
+	case OrderReverseStanding:
+		ordered = g.rankAgents(ordered)
+		slices.Reverse(ordered)
+	}
+	return ordered
+}
+
+// rng devolve um gerador reprodutível a partir de Game.Seed, da ronda e de um
+// sal (fase, votante, ...), para as mesmas escolhas se repetirem com a mesma semente.
+func (g *Game) rng(salt string, roundIndex int) *rand.Rand {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(salt))
+	return rand.New(rand.NewPCG(uint64(g.Seed), h.Sum64()^uint64(roundIndex)))
+}
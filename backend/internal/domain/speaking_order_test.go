@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func agentIDs(agents []*Agent) []string {
+	ids := make([]string, 0, len(agents))
+	for _, a := range agents {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
+
+func TestOrderAgentsFixedAndRotate(t *testing.T) {
+	g := newTestGame(4)
+	g.SpeakingOrder = SpeakingOrder{PhaseDebate: OrderRotate}
+
+	tests := []struct {
+		phase Phase
+		round int
+		want  []string
+	}{
+		{PhaseAnswer, 3, []string{"agent-1", "agent-2", "agent-3", "agent-4"}}, // sem estratégia: fixed
+		{PhaseDebate, 1, []string{"agent-1", "agent-2", "agent-3", "agent-4"}},
+		{PhaseDebate, 2, []string{"agent-2", "agent-3", "agent-4", "agent-1"}},
+		{PhaseDebate, 4, []string{"agent-4", "agent-1", "agent-2", "agent-3"}},
+		{PhaseDebate, 5, []string{"agent-1", "agent-2", "agent-3", "agent-4"}},
+	}
+	for _, tt := range tests {
+		if got := agentIDs(g.OrderAgents(tt.phase, tt.round, g.Agents)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s, ronda %d: %v, want %v", tt.phase, tt.round, got, tt.want)
+		}
+	}
+	if got := agentIDs(g.Agents); !slices.Equal(got, []string{"agent-1", "agent-2", "agent-3", "agent-4"}) {
+		t.Errorf("OrderAgents alterou a lista recebida: %v", got)
+	}
+}
+
+func TestOrderAgentsShuffle(t *testing.T) {
+	newGame := func(seed int64) *Game {
+		g := newTestGame(6)
+		g.Seed = seed
+		g.SpeakingOrder = SpeakingOrder{PhaseAnswer: OrderShuffle, PhaseDebate: OrderShuffle}
+		return g
+	}
+	order := func(g *Game, phase Phase, round int) []string {
+		return agentIDs(g.OrderAgents(phase, round, g.Agents))
+	}
+
+	// A mesma semente dá sempre a mesma ordem, mesmo noutro jogo
+	a, b := newGame(42), newGame(42)
+	for round := 1; round <= 5; round++ {
+		if got, want := order(b, PhaseAnswer, round), order(a, PhaseAnswer, round); !slices.Equal(got, want) {
+			t.Errorf("ronda %d: %v, want %v", round, got, want)
+		}
+	}
+
+	// Mas muda entre rondas, fases e sementes
+	differs := func(name string, other func(round int) []string) {
+		for round := 1; round <= 5; round++ {
+			if !slices.Equal(other(round), order(a, PhaseAnswer, round)) {
+				return
+			}
+		}
+		t.Errorf("%s: a ordem baralhada é sempre a mesma", name)
+	}
+	differs("outra ronda", func(round int) []string { return order(a, PhaseAnswer, round+1) })
+	differs("outra fase", func(round int) []string { return order(a, PhaseDebate, round) })
+	differs("outra semente", func(round int) []string { return order(newGame(7), PhaseAnswer, round) })
+
+	got := order(a, PhaseAnswer, 1)
+	slices.Sort(got)
+	if !slices.Equal(got, agentIDs(a.Agents)) {
+		t.Errorf("a ordem baralhada não tem os mesmos agentes: %v", got)
+	}
+}
+
+func TestOrderAgentsReverseStanding(t *testing.T) {
+	g := newTestGame(4)
+	g.SpeakingOrder = SpeakingOrder{PhaseVote: OrderReverseStanding}
+	g.Agents[0].Strikes = 1
+	g.Agents[2].Strikes = 1
+	// agent-3 recebeu mais votos do que agent-1, por isso está pior
+	g.Rounds = []*Round{{Votes: []Vote{{VoterID: "agent-1", TargetID: "agent-3"}, {VoterID: "agent-2", TargetID: "agent-3"}}}}
+
+	want := []string{"agent-3", "agent-1", "agent-4", "agent-2"}
+	if got := agentIDs(g.OrderAgents(PhaseVote, 2, g.Agents)); !slices.Equal(got, want) {
+		t.Errorf("OrderAgents() = %v, want %v", got, want)
+	}
+}
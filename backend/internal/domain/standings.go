@@ -0,0 +1,111 @@
+package domain
+
+import "sort"
+
+// EndReason explica porque é que o jogo terminou.
+type EndReason string
+
+const (
+	EndReasonFinale                  EndReason = "finale"                   // vencedor decidido na final
+	EndReasonLastStanding            EndReason = "last_standing"            // só restou um agente
+	EndReasonSimultaneousElimination EndReason = "simultaneous_elimination" // os últimos foram eliminados ao mesmo tempo
+)
+
+// Standing é a classificação final de um agente.
+type Standing struct {
+	AgentID         string `json:"agent_id"`
+	Placement       int    `json:"placement"`
+	EliminatedRound int    `json:"eliminated_round,omitempty"` // 0 = não foi eliminado
+	Strikes         int    `json:"strikes"`
+	VotesReceived   int    `json:"votes_received"`
+}
+
+// VotesReceived conta os votos que cada agente recebeu ao longo do jogo.
+func (g *Game) VotesReceived() map[string]int {
+	res := make(map[string]int)
+	for _, r := range g.Rounds {
+		for _, v := range r.Votes {
+			res[v.TargetID]++
+		}
+	}
+	return res
+}
+
+// rankAgents ordena os agentes do melhor para o pior.
+//
+// Quem foi eliminado mais tarde fica à frente. Agentes eliminados na mesma
+// ronda desempatam por menos strikes, depois por menos votos recebidos no
+// jogo todo e, por fim, pela ordem de criação.
+func (g *Game) rankAgents(agents []*Agent) []*Agent {
+	votes := g.VotesReceived()
+	order := make(map[string]int, len(g.Agents))
+	for i, a := range g.Agents {
+		order[a.ID] = i
+	}
+
+	ranked := append([]*Agent(nil), agents...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Eliminated != b.Eliminated {
+			return !a.Eliminated
+		}
+		if a.EliminatedRound != b.EliminatedRound {
+			return a.EliminatedRound > b.EliminatedRound
+		}
+		if a.Strikes != b.Strikes {
+			return a.Strikes < b.Strikes
+		}
+		if votes[a.ID] != votes[b.ID] {
+			return votes[a.ID] < votes[b.ID]
+		}
+		return order[a.ID] < order[b.ID]
+	})
+	return ranked
+}
+
+// Finish termina o jogo, define o vencedor e calcula a classificação final.
+//
+// Se os últimos agentes forem eliminados todos na mesma ronda, o melhor deles
+// segundo o desempate de rankAgents é salvo e vence o jogo.
+func (g *Game) Finish() {
+	switch active := g.ActiveAgents(); {
+	case g.WinnerID != "":
+		g.EndReason = EndReasonFinale
+	case len(active) == 1:
+		g.WinnerID = active[0].ID
+		g.EndReason = EndReasonLastStanding
+	case len(active) == 0 && len(g.Agents) > 0:
+		winner := g.rankAgents(g.Agents)[0]
+		if len(g.Rounds) > 0 {
+			last := g.Rounds[len(g.Rounds)-1]
+			kept := last.Eliminated[:0]
+			for _, id := range last.Eliminated {
+				if id != winner.ID {
+					kept = append(kept, id)
+				}
+			}
+			last.Eliminated = kept
+		}
+		winner.Eliminated = false
+		winner.EliminatedRound = 0
+		g.WinnerID = winner.ID
+		g.EndReason = EndReasonSimultaneousElimination
+	}
+
+	// O vencedor é o único não eliminado, por isso fica sempre em primeiro
+	ranked := g.rankAgents(g.Agents)
+
+	votes := g.VotesReceived()
+	g.Standings = make([]Standing, 0, len(ranked))
+	for i, a := range ranked {
+		g.Standings = append(g.Standings, Standing{
+			AgentID:         a.ID,
+			Placement:       i + 1,
+			EliminatedRound: a.EliminatedRound,
+			Strikes:         a.Strikes,
+			VotesReceived:   votes[a.ID],
+		})
+	}
+
+	g.Status = GameStatusFinished
+}
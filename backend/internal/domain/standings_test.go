@@ -0,0 +1,93 @@
+package domain
+
+import "testing"
+
+func TestFinish(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(g *Game)
+		wantWinner string
+		wantReason EndReason
+		wantOrder  []string
+	}{
+		{
+			name: "último em jogo",
+			setup: func(g *Game) {
+				g.Eliminate(g.Agents[0], &Round{Index: 1})
+				g.Eliminate(g.Agents[2], &Round{Index: 2})
+			},
+			wantWinner: "agent-2",
+			wantReason: EndReasonLastStanding,
+			wantOrder:  []string{"agent-2", "agent-3", "agent-1"},
+		},
+		{
+			name: "vencedor da final",
+			setup: func(g *Game) {
+				g.Eliminate(g.Agents[1], &Round{Index: 1})
+				g.Eliminate(g.Agents[0], &Round{Index: 2})
+				g.WinnerID = "agent-3"
+			},
+			wantWinner: "agent-3",
+			wantReason: EndReasonFinale,
+			wantOrder:  []string{"agent-3", "agent-1", "agent-2"},
+		},
+		{
+			// Eliminados na mesma ronda: vence quem tem menos strikes
+			name: "eliminação simultânea",
+			setup: func(g *Game) {
+				g.Eliminate(g.Agents[0], &Round{Index: 1})
+				last := &Round{Index: 2}
+				g.Agents[1].Strikes = 3
+				g.Agents[2].Strikes = 2
+				g.Eliminate(g.Agents[1], last)
+				g.Eliminate(g.Agents[2], last)
+				g.Rounds = append(g.Rounds, &Round{Index: 1}, last)
+			},
+			wantWinner: "agent-3",
+			wantReason: EndReasonSimultaneousElimination,
+			wantOrder:  []string{"agent-3", "agent-2", "agent-1"},
+		},
+		{
+			// Mesma ronda e mesmos strikes: desempata por menos votos recebidos
+			name: "desempate por votos",
+			setup: func(g *Game) {
+				r := &Round{Index: 1, Votes: []Vote{
+					{VoterID: "agent-2", TargetID: "agent-1"},
+					{VoterID: "agent-3", TargetID: "agent-1"},
+					{VoterID: "agent-1", TargetID: "agent-2"},
+				}}
+				g.Rounds = append(g.Rounds, r)
+				g.Eliminate(g.Agents[0], r)
+				g.Eliminate(g.Agents[1], r)
+			},
+			wantWinner: "agent-3",
+			wantReason: EndReasonLastStanding,
+			wantOrder:  []string{"agent-3", "agent-2", "agent-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(3)
+			tt.setup(g)
+			g.Finish()
+
+			if g.Status != GameStatusFinished {
+				t.Errorf("Status = %s", g.Status)
+			}
+			if g.WinnerID != tt.wantWinner || g.EndReason != tt.wantReason {
+				t.Errorf("vencedor = %s (%s), want %s (%s)", g.WinnerID, g.EndReason, tt.wantWinner, tt.wantReason)
+			}
+			if winner := g.Agent(g.WinnerID); winner == nil || winner.Eliminated {
+				t.Errorf("o vencedor não pode ficar eliminado: %+v", winner)
+			}
+			if len(g.Standings) != len(tt.wantOrder) {
+				t.Fatalf("Standings = %+v", g.Standings)
+			}
+			for i, s := range g.Standings {
+				if s.AgentID != tt.wantOrder[i] || s.Placement != i+1 {
+					t.Errorf("lugar %d = %s (%d), want %s", i+1, s.AgentID, s.Placement, tt.wantOrder[i])
+				}
+			}
+		})
+	}
+}
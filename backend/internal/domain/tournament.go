@@ -0,0 +1,51 @@
+package domain
+
+type TournamentStatus string
+
+const (
+	TournamentStatusGroups   TournamentStatus = "groups"   // a jogar os jogos dos grupos
+	TournamentStatusFinal    TournamentStatus = "final"    // os vencedores dos grupos estão a jogar a final
+	TournamentStatusFinished TournamentStatus = "finished" // a final já tem vencedor
+)
+
+// TournamentGame é um jogo do torneio: o de um grupo ou a final.
+type TournamentGame struct {
+	Group    int    `json:"group,omitempty"` // 1..N nos grupos, 0 na final
+	GameID   string `json:"game_id"`
+	WinnerID string `json:"winner_id,omitempty"` // ID do vencedor dentro do jogo
+}
+
+// Tournament junta vários jogos: cada grupo joga o seu e os vencedores
+// passam à final. Na final o agent-N é o vencedor do grupo N.
+type Tournament struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name,omitempty"`
+	Groups []*TournamentGame `json:"groups"`
+	Final  *TournamentGame   `json:"final,omitempty"`
+	Status TournamentStatus  `json:"status"`
+	Winner *Persona          `json:"winner,omitempty"` // persona do vencedor da final
+}
+
+// NextGame devolve o próximo jogo por acabar: o primeiro grupo sem vencedor
+// ou a final. nil quando faltam criar a final ou o torneio já acabou.
+func (t *Tournament) NextGame() *TournamentGame {
+	for _, g := range t.Groups {
+		if g.WinnerID == "" {
+			return g
+		}
+	}
+	if t.Final != nil && t.Final.WinnerID == "" {
+		return t.Final
+	}
+	return nil
+}
+
+// GroupsDone indica se todos os grupos já têm vencedor.
+func (t *Tournament) GroupsDone() bool {
+	for _, g := range t.Groups {
+		if g.WinnerID == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "testing"
+
+func TestTournamentNextGame(t *testing.T) {
+	// winners: vencedor de cada grupo ("" = por decidir); final: nil sem final
+	tournament := func(final *TournamentGame, winners ...string) *Tournament {
+		tr := &Tournament{Final: final}
+		for i, w := range winners {
+			tr.Groups = append(tr.Groups, &TournamentGame{Group: i + 1, GameID: "g" + string(rune('1'+i)), WinnerID: w})
+		}
+		return tr
+	}
+
+	tests := []struct {
+		name       string
+		tournament *Tournament
+		wantGame   string // "" = nil
+		groupsDone bool
+	}{
+		{name: "nenhum grupo jogado", tournament: tournament(nil, "", ""), wantGame: "g1"},
+		{name: "primeiro grupo decidido", tournament: tournament(nil, "agent-2", ""), wantGame: "g2"},
+		{name: "grupo do meio por decidir", tournament: tournament(nil, "agent-1", "", "agent-3"), wantGame: "g2"},
+		{name: "grupos decididos sem final", tournament: tournament(nil, "agent-1", "agent-2"), groupsDone: true},
+		{name: "final por jogar", tournament: tournament(&TournamentGame{GameID: "final"}, "agent-1", "agent-2"), wantGame: "final", groupsDone: true},
+		{name: "final decidida", tournament: tournament(&TournamentGame{GameID: "final", WinnerID: "agent-2"}, "agent-1", "agent-2"), groupsDone: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ""
+			if next := tt.tournament.NextGame(); next != nil {
+				got = next.GameID
+			}
+			if got != tt.wantGame {
+				t.Errorf("NextGame = %q, want %q", got, tt.wantGame)
+			}
+			if done := tt.tournament.GroupsDone(); done != tt.groupsDone {
+				t.Errorf("GroupsDone = %v, want %v", done, tt.groupsDone)
+			}
+		})
+	}
+}
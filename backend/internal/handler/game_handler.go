@@ -3,13 +3,14 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/rafawastaken/ai-hunger-games/internal/domain"
 	"github.com/rafawastaken/ai-hunger-games/internal/repository"
-	"github.com/rafawastaken/ai-hunger-games/internal/service"
 	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
 )
 
@@ -17,21 +18,25 @@ type GameHandler struct {
 	gameRepo     repository.GameRepository
 	createGameUC *usecase.CreateGameUseCase
 	playRoundUC  *usecase.PlayRoundUseCase
-
-	groqSvc service.GroqService // para streaming
+	autoplayUC   *usecase.AutoplayUseCase
+	exportGameUC *usecase.ExportGameUseCase
+	sessions     *sessions // sessões do público (votação)
 }
 
 func NewGameHandler(
 	gameRepo repository.GameRepository,
 	createGameUC *usecase.CreateGameUseCase,
 	playRoundUC *usecase.PlayRoundUseCase,
-	groqSvc service.GroqService,
+	autoplayUC *usecase.AutoplayUseCase,
+	exportGameUC *usecase.ExportGameUseCase,
 ) *GameHandler {
 	return &GameHandler{
 		gameRepo:     gameRepo,
 		createGameUC: createGameUC,
 		playRoundUC:  playRoundUC,
-		groqSvc:      groqSvc,
+		autoplayUC:   autoplayUC,
+		exportGameUC: exportGameUC,
+		sessions:     newSessions(),
 	}
 }
 
@@ -51,16 +56,60 @@ func (h *GameHandler) handleGames(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodPost:
 		var req struct {
-			NumAgents  int `json:"num_agents"`
-			MaxStrikes int `json:"max_strikes"`
+			NumAgents     int                  `json:"num_agents"`
+			PersonaIDs    []string             `json:"persona_ids"`
+			Personas      []domain.Persona     `json:"personas"`
+			Models        []string             `json:"models"`
+			MaxStrikes    int                  `json:"max_strikes"`
+			FinaleDecider domain.FinaleDecider `json:"finale_decider"`
+			HumanPlayers  []string             `json:"human_players"`
+			HumanTimeout  int                  `json:"human_timeout_seconds"`
+
+			AudienceWindow int      `json:"audience_window_seconds"`
+			AudienceWeight *float64 `json:"audience_weight"`
+
+			Language  domain.Language `json:"language"`
+			PromptSet string          `json:"prompt_set"`
+
+			Theme           string `json:"theme"`
+			ProvocativeHost bool   `json:"provocative_host"`
+
+			SpeakingOrder domain.SpeakingOrder `json:"speaking_order"`
+			Seed          int64                `json:"seed"`
+			BlindVoting   bool                 `json:"blind_voting"`
+			RoundConfig   domain.RoundConfig   `json:"round_config"`
+
+			QuestionFilter *domain.QuestionFilter `json:"question_filter"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, "invalid json", http.StatusBadRequest)
 			return
 		}
 		out, err := h.createGameUC.Execute(usecase.CreateGameInput{
-			NumAgents:  req.NumAgents,
-			MaxStrikes: req.MaxStrikes,
+			NumAgents:     req.NumAgents,
+			PersonaIDs:    req.PersonaIDs,
+			Personas:      req.Personas,
+			Models:        req.Models,
+			MaxStrikes:    req.MaxStrikes,
+			FinaleDecider: req.FinaleDecider,
+			HumanPlayers:  req.HumanPlayers,
+			HumanTimeout:  req.HumanTimeout,
+
+			AudienceWindow: req.AudienceWindow,
+			AudienceWeight: req.AudienceWeight,
+
+			Language:  req.Language,
+			PromptSet: req.PromptSet,
+
+			Theme:           req.Theme,
+			ProvocativeHost: req.ProvocativeHost,
+
+			SpeakingOrder: req.SpeakingOrder,
+			Seed:          req.Seed,
+			BlindVoting:   req.BlindVoting,
+			RoundConfig:   req.RoundConfig,
+
+			QuestionFilter: req.QuestionFilter,
 		})
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -82,8 +131,11 @@ func (h *GameHandler) handleGames(w http.ResponseWriter, r *http.Request) {
 }
 
 // GET  /games/{id}                 -> estado do jogo
+// GET  /games/{id}/export?format=md|html|jsonl -> transcrição do jogo
 // POST /games/{id}/rounds          -> corre 1 ronda (sem streaming)
 // POST /games/{id}/rounds/stream   -> corre 1 ronda em SSE
+// POST /games/{id}/rounds/{n}/submissions -> intervenção de um jogador humano
+// POST /games/{id}/rounds/{n}/audience-votes -> voto do público
 func (h *GameHandler) handleGameByID(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/games/")
 	parts := strings.Split(path, "/")
@@ -102,6 +154,7 @@ func (h *GameHandler) handleGameByID(w http.ResponseWriter, r *http.Request) {
 				http.Error(w, "game not found", http.StatusNotFound)
 				return
 			}
+			h.sessions.ensure(w, r)
 			writeJSON(w, http.StatusOK, game)
 		default:
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
@@ -109,8 +162,70 @@ func (h *GameHandler) handleGameByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// /games/{id}/export
+	if len(parts) == 2 && parts[1] == "export" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleExport(w, r, gameID)
+		return
+	}
+
+	// /games/{id}/autoplay
+	if parts[1] == "autoplay" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if len(parts) == 3 && parts[2] == "stop" {
+			if err := h.autoplayUC.Stop(gameID); err != nil {
+				http.Error(w, h.errorMessage(r, gameID, err), http.StatusConflict)
+				return
+			}
+			writeJSON(w, http.StatusAccepted, map[string]string{"status": "accepted"})
+			return
+		}
+		if len(parts) == 2 {
+			h.handleAutoplay(w, r, gameID)
+			return
+		}
+		http.NotFound(w, r)
+		return
+	}
+
 	// /games/{id}/rounds...
 	if parts[1] == "rounds" {
+		// /games/{id}/rounds/{n}/submissions
+		if len(parts) == 4 && parts[3] == "submissions" {
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			h.handleSubmission(w, r, gameID, parts[2])
+			return
+		}
+
+		// /games/{id}/rounds/{n}/audience-votes
+		if len(parts) == 4 && parts[3] == "audience-votes" {
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			h.handleAudienceVote(w, r, gameID, parts[2])
+			return
+		}
+
+		// /games/{id}/rounds/current/{pause|resume|retry|cancel}
+		if len(parts) == 4 && parts[2] == "current" {
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			h.handleCurrentRound(w, r, gameID, parts[3])
+			return
+		}
+
 		// /games/{id}/rounds/stream
 		if len(parts) >= 3 && parts[2] == "stream" {
 			if r.Method != http.MethodPost {
@@ -126,23 +241,24 @@ func (h *GameHandler) handleGameByID(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
-		var req struct {
-			Question string `json:"question"`
-		}
+		var req playRoundRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, "invalid json", http.StatusBadRequest)
 			return
 		}
 
-		ctx, cancel := context.WithTimeout(r.Context(), 120*time.Second)
+		ctx, cancel := h.roundContext(r, gameID, req.Config, 120*time.Second)
 		defer cancel()
 
 		out, err := h.playRoundUC.Execute(ctx, usecase.PlayRoundInput{
-			GameID:   gameID,
-			Question: req.Question,
+			GameID:       gameID,
+			Question:     req.Question,
+			QuestionID:   req.QuestionID,
+			AutoQuestion: req.AutoQuestion,
+			Config:       req.Config,
 		})
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			h.writeRoundError(w, r, gameID, err)
 			return
 		}
 
@@ -208,208 +324,318 @@ func (h *GameHandler) handlePlayRoundStream(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Ler pergunta do body
-	var req struct {
-		Question string `json:"question"`
-	}
+	var req playRoundRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "invalid json", http.StatusBadRequest)
 		return
 	}
-	if strings.TrimSpace(req.Question) == "" {
-		http.Error(w, "question is required", http.StatusBadRequest)
-		return
-	}
 
-	// Buscar jogo
-	game, err := h.gameRepo.Get(gameID)
+	ctx, cancel := h.roundContext(r, gameID, req.Config, 180*time.Second)
+	defer cancel()
+
+	h.streamRound(w, r, flusher, gameID, func(onEvent usecase.RoundEventFunc) error {
+		_, err := h.playRoundUC.Execute(ctx, usecase.PlayRoundInput{
+			GameID:       gameID,
+			Question:     req.Question,
+			QuestionID:   req.QuestionID,
+			AutoQuestion: req.AutoQuestion,
+			Config:       req.Config,
+			OnEvent:      onEvent,
+		})
+		return err
+	})
+}
+
+// streamRound envia os eventos da ronda por SSE. Enquanto nenhum evento tiver
+// sido enviado ainda podemos responder com um erro HTTP normal.
+func (h *GameHandler) streamRound(w http.ResponseWriter, r *http.Request, flusher http.Flusher, gameID string, play func(usecase.RoundEventFunc) error) {
+	h.sessions.ensure(w, r)
+	started := false
+	err := play(func(event string, payload any) {
+		started = true
+		_ = sseWriteEvent(w, flusher, event, payload)
+	})
 	if err != nil {
-		http.Error(w, "game not found", http.StatusNotFound)
-		return
-	}
-	if game.Status == domain.GameStatusFinished {
-		http.Error(w, "game already finished", http.StatusBadRequest)
-		return
+		if !started {
+			h.writeRoundError(w, r, gameID, err)
+			return
+		}
+		_ = sseWriteEvent(w, flusher, "error", map[string]string{"error": h.errorMessage(r, gameID, err)})
 	}
+}
 
-	activeAgents := game.ActiveAgents()
-	if len(activeAgents) == 0 {
-		http.Error(w, "no active agents in game", http.StatusBadRequest)
-		return
+// playRoundRequest é o body dos endpoints que jogam uma ronda.
+type playRoundRequest struct {
+	Question     string              `json:"question"`
+	QuestionID   string              `json:"question_id"`   // pergunta do banco, em vez de question
+	AutoQuestion bool                `json:"auto_question"` // sem question: o apresentador gera uma
+	Config       *domain.RoundConfig `json:"config"`        // opcional, altera a estrutura só desta ronda
+}
+
+// roundContext limita a duração da ronda. Jogos com humanos ficam à espera
+// das submissões, e quando há timeouts por fase cada intervenção já tem o seu.
+func (h *GameHandler) roundContext(r *http.Request, gameID string, override *domain.RoundConfig, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout = h.roundTimeout(gameID, override, timeout); timeout > 0 {
+		return context.WithTimeout(r.Context(), timeout)
 	}
+	return context.WithCancel(r.Context())
+}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 180*time.Second)
-	defer cancel()
+// roundTimeout devolve o tempo máximo de uma ronda do jogo (0 = sem limite).
+func (h *GameHandler) roundTimeout(gameID string, override *domain.RoundConfig, timeout time.Duration) time.Duration {
+	return gameRoundTimeout(h.gameRepo, gameID, override, timeout)
+}
 
-	round := &domain.Round{
-		Index:    game.NextRoundIndex(),
-		Question: req.Question,
+func gameRoundTimeout(gameRepo repository.GameRepository, gameID string, override *domain.RoundConfig, timeout time.Duration) time.Duration {
+	game, err := gameRepo.Get(gameID)
+	if err != nil {
+		return timeout
+	}
+	if game.HasHumans() || game.RoundConfig.Merge(override).HasTimeouts() {
+		return 0
 	}
+	return timeout + time.Duration(game.AudienceWindow)*time.Second
+}
 
-	// 1) Respostas iniciais (stream: event "answer")
-	for _, agent := range activeAgents {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
+// errorMessage traduz o erro para a língua do jogo. Se o jogo não existir usa
+// o Accept-Language do pedido.
+func (h *GameHandler) errorMessage(r *http.Request, gameID string, err error) string {
+	lang := requestLanguage(r)
+	if game, gerr := h.gameRepo.Get(gameID); gerr == nil {
+		lang = game.Lang()
+	}
+	return usecase.ErrorMessage(err, lang)
+}
 
-		text, err := h.groqSvc.GenerateAnswer(ctx, game, agent, req.Question)
-		if err != nil {
-			_ = sseWriteEvent(w, flusher, "error", map[string]string{"error": err.Error()})
-			return
+// requestLanguage lê a primeira língua suportada do Accept-Language (ex: "en-GB,en;q=0.9").
+func requestLanguage(r *http.Request) domain.Language {
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		base, _, _ := strings.Cut(tag, "-")
+		if lang := domain.Language(strings.ToLower(base)); lang.Valid() {
+			return lang
 		}
+	}
+	return domain.DefaultLanguage
+}
 
-		ans := domain.Answer{
-			AgentID: agent.ID,
-			Text:    text,
-		}
-		round.Answers = append(round.Answers, ans)
+// questionRejectedResponse é o corpo do 422 de uma pergunta recusada.
+type questionRejectedResponse struct {
+	Error   string                 `json:"error"`
+	Code    string                 `json:"code"`
+	Reasons []usecase.RejectReason `json:"reasons"`
+}
+
+// writeRoundError responde com o erro de uma ronda que não chegou a começar.
+// Uma pergunta recusada leva um 422 com os motivos, para o cliente os mostrar.
+func (h *GameHandler) writeRoundError(w http.ResponseWriter, r *http.Request, gameID string, err error) {
+	var rejection *usecase.QuestionRejection
+	if errors.As(err, &rejection) {
+		writeJSON(w, http.StatusUnprocessableEntity, questionRejectedResponse{
+			Error:   h.errorMessage(r, gameID, err),
+			Code:    "question_rejected",
+			Reasons: rejection.Reasons,
+		})
+		return
+	}
+	http.Error(w, h.errorMessage(r, gameID, err), roundErrorStatus(err))
+}
 
-		_ = sseWriteEvent(w, flusher, "answer", ans)
+func roundErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrGameNotFound), errors.Is(err, repository.ErrQuestionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrQuestionGeneration):
+		return http.StatusBadGateway
+	case errors.Is(err, usecase.ErrRoundInProgress), errors.Is(err, usecase.ErrAutoplayRunning), errors.Is(err, usecase.ErrRoundPaused),
+		errors.Is(err, usecase.ErrRoundFailed), errors.Is(err, usecase.ErrNoCurrentRound), errors.Is(err, usecase.ErrRoundNotFailed):
+		return http.StatusConflict
 	}
+	return http.StatusBadRequest
+}
 
-	_ = sseWriteEvent(w, flusher, "phase", map[string]string{"phase": "answers_done"})
+// === Endpoint: /games/{id}/rounds/current/{pause|resume|retry|cancel} ===
 
-	// 2) Debate (stream: event "debate")
-	const debateTurns = 2 // reduzido para economizar tokens
-	for turn := 1; turn <= debateTurns; turn++ {
-		for _, agent := range activeAgents {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
+func (h *GameHandler) handleCurrentRound(w http.ResponseWriter, r *http.Request, gameID string, action string) {
+	var err error
+	switch action {
+	case "pause":
+		err = h.playRoundUC.PauseRound(gameID)
+	case "cancel":
+		err = h.playRoundUC.CancelRound(gameID)
+	case "resume", "retry":
+		h.handleResumeRound(w, r, gameID, action == "retry")
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, h.errorMessage(r, gameID, err), roundErrorStatus(err))
+		return
+	}
 
-			msg, err := h.groqSvc.GenerateDebateMessage(ctx, game, round, agent)
-			if err != nil {
-				_ = sseWriteEvent(w, flusher, "error", map[string]string{"error": err.Error()})
-				return
-			}
+	// A ronda em curso pára na intervenção seguinte; o stream dela envia
+	// round_paused ou round_cancelled
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "accepted"})
+}
 
-			dm := domain.DebateMessage{
-				AgentID: agent.ID,
-				Turn:    turn,
-				Text:    msg,
-			}
-			round.Debate = append(round.Debate, dm)
+// handleResumeRound retoma a ronda em pausa (ou repete a que falhou) e envia
+// os eventos por SSE, como /rounds/stream.
+func (h *GameHandler) handleResumeRound(w http.ResponseWriter, r *http.Request, gameID string, retry bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := h.roundContext(r, gameID, nil, 180*time.Second)
+	defer cancel()
 
-			_ = sseWriteEvent(w, flusher, "debate", dm)
+	h.streamRound(w, r, flusher, gameID, func(onEvent usecase.RoundEventFunc) error {
+		if retry {
+			_, err := h.playRoundUC.Retry(ctx, gameID, onEvent)
+			return err
 		}
-	}
+		_, err := h.playRoundUC.Resume(ctx, gameID, onEvent)
+		return err
+	})
+}
 
-	_ = sseWriteEvent(w, flusher, "phase", map[string]string{"phase": "debate_done"})
+// === Endpoint: /games/{id}/autoplay ===
 
-	// 3) Votos (stream: event "vote")
-	votesCount := make(map[string]int)
-	for _, agent := range activeAgents {
-		votesCount[agent.ID] = 0
+type autoplayRequest struct {
+	Source    usecase.QuestionSource `json:"source"` // list, bank ou generate
+	Questions []string               `json:"questions"`
+	MaxRounds int                    `json:"max_rounds"`
+}
+
+// handleAutoplay joga rondas seguidas até o jogo acabar e envia os eventos de
+// todas as rondas no mesmo stream SSE.
+func (h *GameHandler) handleAutoplay(w http.ResponseWriter, r *http.Request, gameID string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
 	}
 
-	for _, agent := range activeAgents {
-		select {
-		case <-ctx.Done():
+	var req autoplayRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json", http.StatusBadRequest)
 			return
-		default:
 		}
+	}
 
-		targetID, justification, err := h.groqSvc.GenerateVote(ctx, game, round, agent)
-		if err != nil {
-			_ = sseWriteEvent(w, flusher, "error", map[string]string{"error": err.Error()})
-			return
-		}
+	h.streamRound(w, r, flusher, gameID, func(onEvent usecase.RoundEventFunc) error {
+		_, err := h.autoplayUC.Execute(r.Context(), usecase.AutoplayInput{
+			GameID:       gameID,
+			Source:       req.Source,
+			Questions:    req.Questions,
+			MaxRounds:    req.MaxRounds,
+			RoundTimeout: h.roundTimeout(gameID, nil, 180*time.Second),
+			OnEvent:      onEvent,
+		})
+		return err
+	})
+}
 
-		v := domain.Vote{
-			VoterID:       agent.ID,
-			TargetID:      targetID,
-			Justification: justification,
-		}
-		round.Votes = append(round.Votes, v)
-		if _, ok := votesCount[targetID]; ok {
-			votesCount[targetID]++
-		}
+// === Endpoint: /games/{id}/export ===
 
-		_ = sseWriteEvent(w, flusher, "vote", v)
+// exportContentTypes são os Content-Type de cada formato da transcrição.
+var exportContentTypes = map[usecase.ExportFormat]string{
+	usecase.ExportFormatMarkdown: "text/markdown; charset=utf-8",
+	usecase.ExportFormatHTML:     "text/html; charset=utf-8",
+	usecase.ExportFormatJSONL:    "application/x-ndjson",
+}
+
+func (h *GameHandler) handleExport(w http.ResponseWriter, r *http.Request, gameID string) {
+	format := usecase.ExportFormat(strings.ToLower(r.URL.Query().Get("format")))
+	if format == "" || format == "markdown" {
+		format = usecase.ExportFormatMarkdown
 	}
 
-	// 4) Determinar strikes (MAIS votos = pior resposta)
-	if len(activeAgents) > 0 {
-		maxVotes := 0
-		for _, agent := range activeAgents {
-			count := votesCount[agent.ID]
-			if count > maxVotes {
-				maxVotes = count
-			}
-		}
+	data, err := h.exportGameUC.Execute(gameID, format)
+	switch {
+	case errors.Is(err, repository.ErrGameNotFound):
+		http.Error(w, h.errorMessage(r, gameID, err), http.StatusNotFound)
+		return
+	case errors.Is(err, usecase.ErrUnsupportedExportFormat):
+		http.Error(w, h.errorMessage(r, gameID, err), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, "error exporting game", http.StatusInternalServerError)
+		return
+	}
 
-		// Só dá strike se alguém recebeu pelo menos 1 voto
-		if maxVotes > 0 {
-			// Encontrar todos os empatados com max votos
-			var tiedAgents []string
-			for _, agent := range activeAgents {
-				if votesCount[agent.ID] == maxVotes {
-					tiedAgents = append(tiedAgents, agent.ID)
-				}
-			}
+	w.Header().Set("Content-Type", exportContentTypes[format])
+	w.Header().Set("Content-Disposition", `attachment; filename="game-`+gameID+`.`+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
 
-			var strikeTarget string
-
-			// Se houver empate, chamar o Juiz!
-			if len(tiedAgents) > 1 {
-				_ = sseWriteEvent(w, flusher, "phase", map[string]string{"phase": "judge"})
-
-				targetID, justification, err := h.groqSvc.GenerateJudgeVote(ctx, game, round, tiedAgents)
-				if err != nil {
-					_ = sseWriteEvent(w, flusher, "error", map[string]string{"error": err.Error()})
-					return
-				}
-				strikeTarget = targetID
-
-				// Enviar evento do voto do juiz
-				_ = sseWriteEvent(w, flusher, "judge_vote", map[string]interface{}{
-					"target_id":     targetID,
-					"justification": justification,
-					"tied_agents":   tiedAgents,
-				})
-			} else if len(tiedAgents) == 1 {
-				strikeTarget = tiedAgents[0]
-			}
+// === Endpoint: /games/{id}/rounds/{n}/submissions ===
 
-			// Aplicar o strike ao alvo
-			if strikeTarget != "" {
-				for _, agent := range activeAgents {
-					if agent.ID == strikeTarget {
-						agent.Strikes++
-						if agent.Strikes >= game.MaxStrikes {
-							agent.Eliminated = true
-							round.Eliminated = append(round.Eliminated, agent.ID)
-						}
-						break
-					}
-				}
-			}
-		}
+func (h *GameHandler) handleSubmission(w http.ResponseWriter, r *http.Request, gameID string, roundParam string) {
+	roundIndex, err := strconv.Atoi(roundParam)
+	if err != nil {
+		http.Error(w, "invalid round", http.StatusBadRequest)
+		return
 	}
 
-	// 5) Atualizar jogo e mandar round_end
-	game.Rounds = append(game.Rounds, round)
-	if len(game.ActiveAgents()) <= 1 {
-		game.Status = domain.GameStatusFinished
-	} else {
-		game.Status = domain.GameStatusRunning
+	var req usecase.HumanInput
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
 	}
 
-	if err := h.gameRepo.Update(game); err != nil {
-		_ = sseWriteEvent(w, flusher, "error", map[string]string{"error": err.Error()})
+	err = h.playRoundUC.SubmitHumanInput(gameID, roundIndex, req)
+	switch {
+	case errors.Is(err, usecase.ErrNoPendingInput):
+		http.Error(w, h.errorMessage(r, gameID, err), http.StatusConflict)
+		return
+	case errors.Is(err, usecase.ErrInvalidInput):
+		http.Error(w, h.errorMessage(r, gameID, err), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, h.errorMessage(r, gameID, err), http.StatusInternalServerError)
 		return
 	}
 
-	type roundEndPayload struct {
-		Game  *domain.Game  `json:"game"`
-		Round *domain.Round `json:"round"`
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "accepted"})
+}
+
+// === Endpoint: /games/{id}/rounds/{n}/audience-votes ===
+
+func (h *GameHandler) handleAudienceVote(w http.ResponseWriter, r *http.Request, gameID string, roundParam string) {
+	roundIndex, err := strconv.Atoi(roundParam)
+	if err != nil {
+		http.Error(w, "invalid round", http.StatusBadRequest)
+		return
 	}
 
-	_ = sseWriteEvent(w, flusher, "round_end", roundEndPayload{
-		Game:  game,
-		Round: round,
-	})
+	var req struct {
+		TargetID string `json:"target_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+
+	// A sessão vem do cookie dado pelo servidor ao abrir o jogo (sem ele, "")
+	sessionID, _ := h.sessions.get(r)
+	tally, err := h.playRoundUC.CastAudienceVote(gameID, roundIndex, sessionID, req.TargetID)
+	switch {
+	case errors.Is(err, usecase.ErrVotingClosed), errors.Is(err, usecase.ErrAlreadyVoted):
+		http.Error(w, h.errorMessage(r, gameID, err), http.StatusConflict)
+		return
+	case errors.Is(err, usecase.ErrInvalidAudienceVote), errors.Is(err, usecase.ErrSessionRequired):
+		http.Error(w, h.errorMessage(r, gameID, err), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, h.errorMessage(r, gameID, err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]any{"tally": tally})
 }
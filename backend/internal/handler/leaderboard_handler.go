@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+type LeaderboardHandler struct {
+	ratingUC *usecase.RatingUseCase
+}
+
+func NewLeaderboardHandler(ratingUC *usecase.RatingUseCase) *LeaderboardHandler {
+	return &LeaderboardHandler{ratingUC: ratingUC}
+}
+
+func (h *LeaderboardHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/leaderboard", h.handleLeaderboard)
+	mux.HandleFunc("/leaderboard/recompute", h.handleRecompute)
+}
+
+// GET /leaderboard?model=&persona=&min_games=&limit= -> classificações Elo
+func (h *LeaderboardHandler) handleLeaderboard(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	input := usecase.LeaderboardInput{
+		Filter: domain.RatingFilter{
+			Model:   query.Get("model"),
+			Persona: query.Get("persona"),
+		},
+	}
+	var err error
+	if input.Filter.MinGames, err = queryInt(query, "min_games"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if input.Limit, err = queryInt(query, "limit"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ratings, err := h.ratingUC.Leaderboard(input)
+	if err != nil {
+		http.Error(w, "error loading leaderboard", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, ratings)
+}
+
+// queryInt lê um parâmetro inteiro não negativo (0 se não vier).
+func queryInt(query url.Values, name string) (int, error) {
+	v := query.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+	return n, nil
+}
+
+// POST /leaderboard/recompute -> refaz as classificações a partir dos jogos guardados
+func (h *LeaderboardHandler) handleRecompute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	out, err := h.ratingUC.Recompute()
+	if err != nil {
+		http.Error(w, "error recomputing ratings", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, out)
+}
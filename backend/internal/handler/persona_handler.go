@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+// maxPackSize limita o tamanho dos packs de personas importados.
+const maxPackSize = 1 << 20
+
+type PersonaHandler struct {
+	personaRepo repository.PersonaRepository
+	libraryUC   *usecase.PersonaLibraryUseCase
+}
+
+func NewPersonaHandler(
+	personaRepo repository.PersonaRepository,
+	libraryUC *usecase.PersonaLibraryUseCase,
+) *PersonaHandler {
+	return &PersonaHandler{
+		personaRepo: personaRepo,
+		libraryUC:   libraryUC,
+	}
+}
+
+func (h *PersonaHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/personas", h.handlePersonas)
+	mux.HandleFunc("/personas/", h.handlePersonaByID)
+}
+
+// POST /personas  -> cria persona
+// GET  /personas  -> lista personas
+func (h *PersonaHandler) handlePersonas(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req domain.Persona
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json", http.StatusBadRequest)
+			return
+		}
+		p, err := h.libraryUC.Create(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusCreated, p)
+
+	case http.MethodGet:
+		personas, err := h.personaRepo.List()
+		if err != nil {
+			http.Error(w, "error listing personas", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, personas)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// GET    /personas/{id}                   -> persona
+// PUT    /personas/{id}                   -> atualiza persona
+// DELETE /personas/{id}                   -> apaga persona
+// POST   /personas/import?format=json|yaml -> importa pack
+// GET    /personas/export?format=json|yaml -> exporta pack
+func (h *PersonaHandler) handlePersonaByID(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/personas/")
+	if id == "" || strings.Contains(id, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch id {
+	case "import":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleImport(w, r)
+		return
+	case "export":
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleExport(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		p, err := h.personaRepo.Get(id)
+		if err != nil {
+			http.Error(w, "persona not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+
+	case http.MethodPut:
+		var req domain.Persona
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json", http.StatusBadRequest)
+			return
+		}
+		p, err := h.libraryUC.Update(id, req)
+		if err != nil {
+			http.Error(w, err.Error(), personaErrorStatus(err))
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+
+	case http.MethodDelete:
+		if err := h.libraryUC.Delete(id); err != nil {
+			http.Error(w, err.Error(), personaErrorStatus(err))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PersonaHandler) handleImport(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxPackSize))
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+
+	personas, err := h.libraryUC.Import(data, packFormat(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusCreated, personas)
+}
+
+func (h *PersonaHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	if raw := r.URL.Query().Get("ids"); raw != "" {
+		ids = strings.Split(raw, ",")
+	}
+
+	format := packFormat(r)
+	data, err := h.libraryUC.Export(ids, format)
+	if err != nil {
+		http.Error(w, err.Error(), personaErrorStatus(err))
+		return
+	}
+
+	contentType := "application/json"
+	if format == usecase.PackFormatYAML {
+		contentType = "application/yaml"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="personas.`+string(format)+`"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// packFormat lê o formato do ?format= ou, na falta dele, do Content-Type. Default: json.
+func packFormat(r *http.Request) usecase.PackFormat {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" && strings.Contains(r.Header.Get("Content-Type"), "yaml") {
+		format = "yaml"
+	}
+	switch format {
+	case "", "json":
+		return usecase.PackFormatJSON
+	case "yml", "yaml":
+		return usecase.PackFormatYAML
+	default:
+		return usecase.PackFormat(format)
+	}
+}
+
+func personaErrorStatus(err error) int {
+	if errors.Is(err, repository.ErrPersonaNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+// maxQuestionsSize limita o tamanho dos ficheiros de perguntas importados.
+const maxQuestionsSize = 4 << 20
+
+type QuestionHandler struct {
+	questionRepo repository.QuestionRepository
+	bankUC       *usecase.QuestionBankUseCase
+}
+
+func NewQuestionHandler(
+	questionRepo repository.QuestionRepository,
+	bankUC *usecase.QuestionBankUseCase,
+) *QuestionHandler {
+	return &QuestionHandler{
+		questionRepo: questionRepo,
+		bankUC:       bankUC,
+	}
+}
+
+func (h *QuestionHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/questions", h.handleQuestions)
+	mux.HandleFunc("/questions/", h.handleQuestionByID)
+}
+
+// POST /questions -> cria pergunta
+// GET  /questions?category=&tag=&difficulty=&language=&unused=true -> lista perguntas
+func (h *QuestionHandler) handleQuestions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req domain.Question
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json", http.StatusBadRequest)
+			return
+		}
+		q, err := h.bankUC.Create(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusCreated, q)
+
+	case http.MethodGet:
+		query := r.URL.Query()
+		filter := &domain.QuestionFilter{
+			Category:   query.Get("category"),
+			Tags:       query["tag"],
+			Difficulty: domain.Difficulty(query.Get("difficulty")),
+			NoRepeats:  query.Get("unused") == "true",
+		}
+		questions, err := h.bankUC.List(filter, domain.Language(query.Get("language")))
+		if err != nil {
+			http.Error(w, "error listing questions", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, questions)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// GET    /questions/{id}                  -> pergunta
+// PUT    /questions/{id}                  -> atualiza pergunta
+// DELETE /questions/{id}                  -> apaga pergunta
+// POST   /questions/import?format=json|csv -> importa perguntas em bloco
+func (h *QuestionHandler) handleQuestionByID(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/questions/")
+	if id == "" || strings.Contains(id, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if id == "import" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleImport(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		q, err := h.questionRepo.Get(id)
+		if err != nil {
+			http.Error(w, "question not found", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, q)
+
+	case http.MethodPut:
+		var req domain.Question
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json", http.StatusBadRequest)
+			return
+		}
+		q, err := h.bankUC.Update(id, req)
+		if err != nil {
+			http.Error(w, err.Error(), questionErrorStatus(err))
+			return
+		}
+		writeJSON(w, http.StatusOK, q)
+
+	case http.MethodDelete:
+		if err := h.bankUC.Delete(id); err != nil {
+			http.Error(w, err.Error(), questionErrorStatus(err))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *QuestionHandler) handleImport(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxQuestionsSize))
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+
+	questions, err := h.bankUC.Import(data, questionFormat(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusCreated, questions)
+}
+
+// questionFormat lê o formato do ?format= ou, na falta dele, do Content-Type. Default: json.
+func questionFormat(r *http.Request) usecase.QuestionFormat {
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" && strings.Contains(r.Header.Get("Content-Type"), "csv") {
+		format = "csv"
+	}
+	if format == "" {
+		return usecase.QuestionFormatJSON
+	}
+	return usecase.QuestionFormat(format)
+}
+
+func questionErrorStatus(err error) int {
+	if errors.Is(err, repository.ErrQuestionNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
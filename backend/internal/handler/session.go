@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// sessionCookie guarda a sessão do público, que conta um voto por ronda.
+const sessionCookie = "hunger_session"
+
+// sessions emite as sessões do público. O ID é gerado pelo servidor e
+// assinado, por isso o cliente não pode inventar outro para votar de novo.
+// A chave é gerada ao arrancar: depois de reiniciar, as sessões são novas.
+type sessions struct {
+	key []byte
+}
+
+func newSessions() *sessions {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return &sessions{key: key}
+}
+
+func (s *sessions) sign(id string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(id))
+	return id + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// get devolve a sessão do pedido, se tiver um cookie assinado por este servidor.
+func (s *sessions) get(r *http.Request) (string, bool) {
+	c, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return "", false
+	}
+	id, _, ok := strings.Cut(c.Value, ".")
+	if !ok || !hmac.Equal([]byte(c.Value), []byte(s.sign(id))) {
+		return "", false
+	}
+	return id, true
+}
+
+// ensure dá uma sessão ao cliente no primeiro contacto (ao abrir o jogo).
+// Tem de ser chamado antes de escrever a resposta.
+func (s *sessions) ensure(w http.ResponseWriter, r *http.Request) string {
+	if id, ok := s.get(r); ok {
+		return id
+	}
+	id := uuid.NewString()
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    s.sign(id),
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return id
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSessions(t *testing.T) {
+	s := newSessions()
+
+	// Primeiro contacto: o servidor dá a sessão num cookie
+	rec := httptest.NewRecorder()
+	id := s.ensure(rec, httptest.NewRequest(http.MethodGet, "/games/g", nil))
+	cookies := rec.Result().Cookies()
+	if id == "" || len(cookies) != 1 || cookies[0].Name != sessionCookie {
+		t.Fatalf("ensure() = %q, cookies %v", id, cookies)
+	}
+
+	withCookie := func(value string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/games/g/rounds/1/audience-votes", nil)
+		r.AddCookie(&http.Cookie{Name: sessionCookie, Value: value})
+		return r
+	}
+	if got, ok := s.get(withCookie(cookies[0].Value)); !ok || got != id {
+		t.Errorf("get() = %q, %v, want %q", got, ok, id)
+	}
+	// Com o cookie já dado, ensure não cria outra sessão
+	rec = httptest.NewRecorder()
+	if got := s.ensure(rec, withCookie(cookies[0].Value)); got != id || len(rec.Result().Cookies()) != 0 {
+		t.Errorf("ensure() com cookie = %q (%d cookies novos), want %q", got, len(rec.Result().Cookies()), id)
+	}
+
+	for _, forged := range []string{"sala-1", id, id + ".abc", "outra" + cookies[0].Value[len(id):]} {
+		if _, ok := s.get(withCookie(forged)); ok {
+			t.Errorf("get() aceitou o cookie inventado %q", forged)
+		}
+	}
+	if _, ok := newSessions().get(withCookie(cookies[0].Value)); ok {
+		t.Error("get() aceitou um cookie de outro servidor")
+	}
+	if _, ok := s.get(httptest.NewRequest(http.MethodPost, "/", nil)); ok {
+		t.Error("get() sem cookie devolveu uma sessão")
+	}
+}
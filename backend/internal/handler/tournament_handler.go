@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+	"github.com/rafawastaken/ai-hunger-games/internal/usecase"
+)
+
+type TournamentHandler struct {
+	gameRepo     repository.GameRepository
+	tournamentUC *usecase.TournamentUseCase
+}
+
+func NewTournamentHandler(
+	gameRepo repository.GameRepository,
+	tournamentUC *usecase.TournamentUseCase,
+) *TournamentHandler {
+	return &TournamentHandler{
+		gameRepo:     gameRepo,
+		tournamentUC: tournamentUC,
+	}
+}
+
+func (h *TournamentHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/tournaments", h.handleTournaments)
+	mux.HandleFunc("/tournaments/", h.handleTournamentByID)
+}
+
+// POST /tournaments -> cria torneio (e os jogos dos grupos)
+// GET  /tournaments -> lista torneios
+func (h *TournamentHandler) handleTournaments(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req struct {
+			Name       string           `json:"name"`
+			Groups     int              `json:"groups"`
+			GroupSize  int              `json:"group_size"`
+			PersonaIDs []string         `json:"persona_ids"`
+			Personas   []domain.Persona `json:"personas"`
+			Models     []string         `json:"models"` // modelo de cada lugar de um grupo
+
+			// Configuração de todos os jogos, como em POST /games
+			MaxStrikes    int                  `json:"max_strikes"`
+			FinaleDecider domain.FinaleDecider `json:"finale_decider"`
+
+			AudienceWindow int      `json:"audience_window_seconds"`
+			AudienceWeight *float64 `json:"audience_weight"`
+
+			Language  domain.Language `json:"language"`
+			PromptSet string          `json:"prompt_set"`
+
+			Theme           string `json:"theme"`
+			ProvocativeHost bool   `json:"provocative_host"`
+
+			SpeakingOrder domain.SpeakingOrder `json:"speaking_order"`
+			Seed          int64                `json:"seed"`
+			BlindVoting   bool                 `json:"blind_voting"`
+			RoundConfig   domain.RoundConfig   `json:"round_config"`
+
+			QuestionFilter *domain.QuestionFilter `json:"question_filter"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json", http.StatusBadRequest)
+			return
+		}
+		tournament, err := h.tournamentUC.Create(usecase.CreateTournamentInput{
+			Name:       req.Name,
+			Groups:     req.Groups,
+			GroupSize:  req.GroupSize,
+			PersonaIDs: req.PersonaIDs,
+			Personas:   req.Personas,
+			Game: usecase.CreateGameInput{
+				Models: req.Models,
+
+				MaxStrikes:    req.MaxStrikes,
+				FinaleDecider: req.FinaleDecider,
+
+				AudienceWindow: req.AudienceWindow,
+				AudienceWeight: req.AudienceWeight,
+
+				Language:  req.Language,
+				PromptSet: req.PromptSet,
+
+				Theme:           req.Theme,
+				ProvocativeHost: req.ProvocativeHost,
+
+				SpeakingOrder: req.SpeakingOrder,
+				Seed:          req.Seed,
+				BlindVoting:   req.BlindVoting,
+				RoundConfig:   req.RoundConfig,
+
+				QuestionFilter: req.QuestionFilter,
+			},
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusCreated, tournament)
+
+	case http.MethodGet:
+		tournaments, err := h.tournamentUC.List()
+		if err != nil {
+			http.Error(w, "error listing tournaments", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, tournaments)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// GET  /tournaments/{id}         -> estado do torneio
+// GET  /tournaments/{id}/bracket -> jogos e participantes de cada fase
+// POST /tournaments/{id}/advance -> joga o próximo jogo em SSE
+func (h *TournamentHandler) handleTournamentByID(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/tournaments/"), "/")
+	if parts[0] == "" || len(parts) > 2 {
+		http.NotFound(w, r)
+		return
+	}
+	id := parts[0]
+
+	action := ""
+	if len(parts) == 2 {
+		action = parts[1]
+	}
+	switch action {
+	case "":
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		tournament, err := h.tournamentUC.Get(id)
+		if err != nil {
+			http.Error(w, usecase.ErrorMessage(err, requestLanguage(r)), tournamentErrorStatus(err))
+			return
+		}
+		writeJSON(w, http.StatusOK, tournament)
+
+	case "bracket":
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		bracket, err := h.tournamentUC.Bracket(id)
+		if err != nil {
+			http.Error(w, usecase.ErrorMessage(err, requestLanguage(r)), tournamentErrorStatus(err))
+			return
+		}
+		writeJSON(w, http.StatusOK, bracket)
+
+	case "advance":
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.handleAdvance(w, r, id)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// handleAdvance joga o próximo jogo do torneio com o autoplay e envia os
+// eventos de todas as rondas no mesmo stream SSE, como /games/{id}/autoplay.
+func (h *TournamentHandler) handleAdvance(w http.ResponseWriter, r *http.Request, id string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	var req autoplayRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "invalid json", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Os jogos do torneio têm todos a mesma configuração
+	var roundTimeout time.Duration
+	if tournament, err := h.tournamentUC.Get(id); err == nil && len(tournament.Groups) > 0 {
+		roundTimeout = gameRoundTimeout(h.gameRepo, tournament.Groups[0].GameID, nil, 180*time.Second)
+	}
+
+	started := false
+	_, err := h.tournamentUC.Advance(r.Context(), usecase.AdvanceTournamentInput{
+		TournamentID: id,
+		Source:       req.Source,
+		Questions:    req.Questions,
+		MaxRounds:    req.MaxRounds,
+		RoundTimeout: roundTimeout,
+		OnEvent: func(event string, payload any) {
+			started = true
+			_ = sseWriteEvent(w, flusher, event, payload)
+		},
+	})
+	if err != nil {
+		msg := usecase.ErrorMessage(err, requestLanguage(r))
+		if !started {
+			http.Error(w, msg, tournamentErrorStatus(err))
+			return
+		}
+		_ = sseWriteEvent(w, flusher, "error", map[string]string{"error": msg})
+	}
+}
+
+func tournamentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrTournamentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrTournamentFinished), errors.Is(err, usecase.ErrTournamentAdvancing):
+		return http.StatusConflict
+	}
+	return roundErrorStatus(err)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// FileGameRepository guarda os jogos em memória e grava cada um em
+// dir/<id>.json, para os jogos (e as rondas em pausa) sobreviverem a um
+// restart do servidor.
+type FileGameRepository struct {
+	mem   *InMemoryGameRepository
+	store *fileStore
+}
+
+// NewFileGameRepository cria o diretório se preciso e carrega os jogos já gravados.
+func NewFileGameRepository(dir string) (*FileGameRepository, error) {
+	store, err := newFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &FileGameRepository{
+		mem:   NewInMemoryGameRepository(),
+		store: store,
+	}
+	err = load(store, func(game *domain.Game) {
+		_ = r.mem.Create(game)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *FileGameRepository) Create(game *domain.Game) error {
+	if err := r.mem.Create(game); err != nil {
+		return err
+	}
+	return r.store.save(game.ID, game)
+}
+
+func (r *FileGameRepository) Update(game *domain.Game) error {
+	if err := r.mem.Update(game); err != nil {
+		return err
+	}
+	return r.store.save(game.ID, game)
+}
+
+func (r *FileGameRepository) Delete(id string) error {
+	if err := r.mem.Delete(id); err != nil {
+		return err
+	}
+	return r.store.remove(id)
+}
+
+func (r *FileGameRepository) Get(id string) (*domain.Game, error) {
+	return r.mem.Get(id)
+}
+
+func (r *FileGameRepository) List() ([]*domain.Game, error) {
+	return r.mem.List()
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// FilePersonaRepository guarda a biblioteca de personas em memória e grava
+// cada persona em dir/<id>.json.
+type FilePersonaRepository struct {
+	mem   *InMemoryPersonaRepository
+	store *fileStore
+}
+
+// NewFilePersonaRepository cria o diretório se preciso e carrega as personas já gravadas.
+func NewFilePersonaRepository(dir string) (*FilePersonaRepository, error) {
+	store, err := newFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &FilePersonaRepository{
+		mem:   NewInMemoryPersonaRepository(),
+		store: store,
+	}
+	err = load(store, func(persona *domain.Persona) {
+		_ = r.mem.Create(persona)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *FilePersonaRepository) Create(persona *domain.Persona) error {
+	if err := r.mem.Create(persona); err != nil {
+		return err
+	}
+	return r.store.save(persona.ID, persona)
+}
+
+func (r *FilePersonaRepository) Update(persona *domain.Persona) error {
+	if err := r.mem.Update(persona); err != nil {
+		return err
+	}
+	return r.store.save(persona.ID, persona)
+}
+
+func (r *FilePersonaRepository) Delete(id string) error {
+	if err := r.mem.Delete(id); err != nil {
+		return err
+	}
+	return r.store.remove(id)
+}
+
+func (r *FilePersonaRepository) Get(id string) (*domain.Persona, error) {
+	return r.mem.Get(id)
+}
+
+func (r *FilePersonaRepository) List() ([]*domain.Persona, error) {
+	return r.mem.List()
+}
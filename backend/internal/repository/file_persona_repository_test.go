@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// As personas gravadas voltam depois de reabrir o repositório (reiniciar o servidor).
+func TestFilePersonaRepository(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := NewFilePersonaRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []*domain.Persona{{ID: "p1", Name: "Cínica"}, {ID: "p2", Name: "Otimista"}} {
+		if err := repo.Create(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repo.Update(&domain.Persona{ID: "p1", Name: "Muito cínica"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete("p2"); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFilePersonaRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, err := reopened.Get("p1"); err != nil || p.Name != "Muito cínica" {
+		t.Errorf("Get(p1) = %+v, %v, want Muito cínica", p, err)
+	}
+	if _, err := reopened.Get("p2"); !errors.Is(err, ErrPersonaNotFound) {
+		t.Errorf("Get(p2) err = %v, want %v", err, ErrPersonaNotFound)
+	}
+}
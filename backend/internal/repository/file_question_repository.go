@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// FileQuestionRepository guarda o banco de perguntas em memória e grava cada
+// pergunta em dir/<id>.json, com as contagens de uso.
+type FileQuestionRepository struct {
+	mem   *InMemoryQuestionRepository
+	store *fileStore
+}
+
+// NewFileQuestionRepository cria o diretório se preciso e carrega as perguntas já gravadas.
+func NewFileQuestionRepository(dir string) (*FileQuestionRepository, error) {
+	store, err := newFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &FileQuestionRepository{
+		mem:   NewInMemoryQuestionRepository(),
+		store: store,
+	}
+	err = load(store, func(question *domain.Question) {
+		_ = r.mem.Create(question)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *FileQuestionRepository) Create(question *domain.Question) error {
+	if err := r.mem.Create(question); err != nil {
+		return err
+	}
+	return r.store.save(question.ID, question)
+}
+
+func (r *FileQuestionRepository) Update(question *domain.Question) error {
+	if err := r.mem.Update(question); err != nil {
+		return err
+	}
+	return r.store.save(question.ID, question)
+}
+
+func (r *FileQuestionRepository) Delete(id string) error {
+	if err := r.mem.Delete(id); err != nil {
+		return err
+	}
+	return r.store.remove(id)
+}
+
+func (r *FileQuestionRepository) Get(id string) (*domain.Question, error) {
+	return r.mem.Get(id)
+}
+
+func (r *FileQuestionRepository) List() ([]*domain.Question, error) {
+	return r.mem.List()
+}
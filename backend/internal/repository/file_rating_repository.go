@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// ratingFileID é o nome do ficheiro (sem .json) onde fica a tabela.
+const ratingFileID = "ratings"
+
+// FileRatingRepository guarda a tabela de classificações em memória e grava-a
+// em dir/ratings.json.
+type FileRatingRepository struct {
+	mem   *InMemoryRatingRepository
+	store *fileStore
+}
+
+// NewFileRatingRepository cria o diretório se preciso e carrega a tabela já gravada.
+func NewFileRatingRepository(dir string) (*FileRatingRepository, error) {
+	store, err := newFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &FileRatingRepository{
+		mem:   NewInMemoryRatingRepository(),
+		store: store,
+	}
+	table := domain.NewRatingTable()
+	ok, err := store.read(ratingFileID, table)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		_ = r.mem.Save(table)
+	}
+	return r, nil
+}
+
+func (r *FileRatingRepository) Load() (*domain.RatingTable, error) {
+	return r.mem.Load()
+}
+
+func (r *FileRatingRepository) Save(table *domain.RatingTable) error {
+	if err := r.mem.Save(table); err != nil {
+		return err
+	}
+	return r.store.save(ratingFileID, table)
+}
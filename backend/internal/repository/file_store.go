@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// fileStore grava entidades em dir/<id>.json. É a parte comum dos
+// repositórios em ficheiro, que guardam tudo em memória e gravam cada
+// alteração.
+type fileStore struct {
+	dir string
+
+	writeMu sync.Mutex
+}
+
+// newFileStore cria o diretório se preciso.
+func newFileStore(dir string) (*fileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+// load lê todos os ficheiros gravados e chama add com cada um.
+func load[T any](s *fileStore, add func(*T)) error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		v := new(T)
+		if err := json.Unmarshal(data, v); err != nil {
+			return fmt.Errorf("%s: %w", f, err)
+		}
+		add(v)
+	}
+	return nil
+}
+
+// read lê dir/<id>.json para v. Devolve false se o ficheiro não existir.
+func (s *fileStore) read(id string, v any) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("%s.json: %w", id, err)
+	}
+	return true, nil
+}
+
+// save escreve para um ficheiro temporário e renomeia, para um crash a meio
+// nunca deixar um ficheiro meio escrito.
+func (s *fileStore) save(id string, v any) error {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return fmt.Errorf("invalid id: %q", id)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dir, id+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// remove apaga dir/<id>.json; um ficheiro que já não existe não é erro.
+func (s *fileStore) remove(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return fmt.Errorf("invalid id: %q", id)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	err := os.Remove(filepath.Join(s.dir, id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
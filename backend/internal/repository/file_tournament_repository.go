@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// FileTournamentRepository guarda os torneios em memória e grava cada um em
+// dir/<id>.json, ao lado dos jogos gravados pelo FileGameRepository.
+type FileTournamentRepository struct {
+	mem   *InMemoryTournamentRepository
+	store *fileStore
+}
+
+// NewFileTournamentRepository cria o diretório se preciso e carrega os torneios já gravados.
+func NewFileTournamentRepository(dir string) (*FileTournamentRepository, error) {
+	store, err := newFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &FileTournamentRepository{
+		mem:   NewInMemoryTournamentRepository(),
+		store: store,
+	}
+	err = load(store, func(tournament *domain.Tournament) {
+		_ = r.mem.Create(tournament)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *FileTournamentRepository) Create(tournament *domain.Tournament) error {
+	if err := r.mem.Create(tournament); err != nil {
+		return err
+	}
+	return r.store.save(tournament.ID, tournament)
+}
+
+func (r *FileTournamentRepository) Update(tournament *domain.Tournament) error {
+	if err := r.mem.Update(tournament); err != nil {
+		return err
+	}
+	return r.store.save(tournament.ID, tournament)
+}
+
+func (r *FileTournamentRepository) Get(id string) (*domain.Tournament, error) {
+	return r.mem.Get(id)
+}
+
+func (r *FileTournamentRepository) List() ([]*domain.Tournament, error) {
+	return r.mem.List()
+}
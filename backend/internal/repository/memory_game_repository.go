@@ -12,6 +12,7 @@ var ErrGameNotFound = errors.New("game not found")
 type GameRepository interface {
 	Create(game *domain.Game) error
 	Update(game *domain.Game) error
+	Delete(id string) error
 	Get(id string) (*domain.Game, error)
 	List() ([]*domain.Game, error)
 }
@@ -44,6 +45,16 @@ func (r *InMemoryGameRepository) Update(game *domain.Game) error {
 	return nil
 }
 
+func (r *InMemoryGameRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.games[id]; !ok {
+		return ErrGameNotFound
+	}
+	delete(r.games, id)
+	return nil
+}
+
 func (r *InMemoryGameRepository) Get(id string) (*domain.Game, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+var ErrPersonaNotFound = errors.New("persona not found")
+
+type PersonaRepository interface {
+	Create(persona *domain.Persona) error
+	Update(persona *domain.Persona) error
+	Delete(id string) error
+	Get(id string) (*domain.Persona, error)
+	List() ([]*domain.Persona, error)
+}
+
+type InMemoryPersonaRepository struct {
+	mu       sync.RWMutex
+	personas map[string]*domain.Persona
+}
+
+func NewInMemoryPersonaRepository() *InMemoryPersonaRepository {
+	return &InMemoryPersonaRepository{
+		personas: make(map[string]*domain.Persona),
+	}
+}
+
+func (r *InMemoryPersonaRepository) Create(persona *domain.Persona) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.personas[persona.ID] = persona
+	return nil
+}
+
+func (r *InMemoryPersonaRepository) Update(persona *domain.Persona) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.personas[persona.ID]; !ok {
+		return ErrPersonaNotFound
+	}
+	r.personas[persona.ID] = persona
+	return nil
+}
+
+func (r *InMemoryPersonaRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.personas[id]; !ok {
+		return ErrPersonaNotFound
+	}
+	delete(r.personas, id)
+	return nil
+}
+
+func (r *InMemoryPersonaRepository) Get(id string) (*domain.Persona, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	persona, ok := r.personas[id]
+	if !ok {
+		return nil, ErrPersonaNotFound
+	}
+	return persona, nil
+}
+
+func (r *InMemoryPersonaRepository) List() ([]*domain.Persona, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]*domain.Persona, 0, len(r.personas))
+	for _, p := range r.personas {
+		res = append(res, p)
+	}
+	// Ordem estável para listagens e exports
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+var ErrQuestionNotFound = errors.New("question not found")
+
+type QuestionRepository interface {
+	Create(question *domain.Question) error
+	Update(question *domain.Question) error
+	Delete(id string) error
+	Get(id string) (*domain.Question, error)
+	List() ([]*domain.Question, error)
+}
+
+type InMemoryQuestionRepository struct {
+	mu        sync.RWMutex
+	questions map[string]*domain.Question
+}
+
+func NewInMemoryQuestionRepository() *InMemoryQuestionRepository {
+	return &InMemoryQuestionRepository{
+		questions: make(map[string]*domain.Question),
+	}
+}
+
+func (r *InMemoryQuestionRepository) Create(question *domain.Question) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.questions[question.ID] = question
+	return nil
+}
+
+func (r *InMemoryQuestionRepository) Update(question *domain.Question) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.questions[question.ID]; !ok {
+		return ErrQuestionNotFound
+	}
+	r.questions[question.ID] = question
+	return nil
+}
+
+func (r *InMemoryQuestionRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.questions[id]; !ok {
+		return ErrQuestionNotFound
+	}
+	delete(r.questions, id)
+	return nil
+}
+
+func (r *InMemoryQuestionRepository) Get(id string) (*domain.Question, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	question, ok := r.questions[id]
+	if !ok {
+		return nil, ErrQuestionNotFound
+	}
+	return question, nil
+}
+
+func (r *InMemoryQuestionRepository) List() ([]*domain.Question, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]*domain.Question, 0, len(r.questions))
+	for _, q := range r.questions {
+		res = append(res, q)
+	}
+	// Ordem estável para listagens e para a escolha reprodutível das perguntas
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Text != res[j].Text {
+			return res[i].Text < res[j].Text
+		}
+		return res[i].ID < res[j].ID
+	})
+	return res, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"sync"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// RatingRepository guarda a tabela de classificações inteira: as
+// classificações são sempre atualizadas em conjunto, jogo a jogo.
+type RatingRepository interface {
+	Load() (*domain.RatingTable, error)
+	Save(table *domain.RatingTable) error
+}
+
+type InMemoryRatingRepository struct {
+	mu    sync.RWMutex
+	table *domain.RatingTable
+}
+
+func NewInMemoryRatingRepository() *InMemoryRatingRepository {
+	return &InMemoryRatingRepository{
+		table: domain.NewRatingTable(),
+	}
+}
+
+func (r *InMemoryRatingRepository) Load() (*domain.RatingTable, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.table, nil
+}
+
+func (r *InMemoryRatingRepository) Save(table *domain.RatingTable) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.table = table
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+var ErrTournamentNotFound = errors.New("tournament not found")
+
+type TournamentRepository interface {
+	Create(tournament *domain.Tournament) error
+	Update(tournament *domain.Tournament) error
+	Get(id string) (*domain.Tournament, error)
+	List() ([]*domain.Tournament, error)
+}
+
+type InMemoryTournamentRepository struct {
+	mu          sync.RWMutex
+	tournaments map[string]*domain.Tournament
+}
+
+func NewInMemoryTournamentRepository() *InMemoryTournamentRepository {
+	return &InMemoryTournamentRepository{
+		tournaments: make(map[string]*domain.Tournament),
+	}
+}
+
+func (r *InMemoryTournamentRepository) Create(tournament *domain.Tournament) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tournaments[tournament.ID] = tournament
+	return nil
+}
+
+func (r *InMemoryTournamentRepository) Update(tournament *domain.Tournament) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tournaments[tournament.ID]; !ok {
+		return ErrTournamentNotFound
+	}
+	r.tournaments[tournament.ID] = tournament
+	return nil
+}
+
+func (r *InMemoryTournamentRepository) Get(id string) (*domain.Tournament, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tournament, ok := r.tournaments[id]
+	if !ok {
+		return nil, ErrTournamentNotFound
+	}
+	return tournament, nil
+}
+
+func (r *InMemoryTournamentRepository) List() ([]*domain.Tournament, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]*domain.Tournament, 0, len(r.tournaments))
+	for _, t := range r.tournaments {
+		res = append(res, t)
+	}
+	return res, nil
+}
@@ -13,25 +13,53 @@ import (
 )
 
 type GroqService interface {
-	GenerateAnswer(ctx context.Context, game *domain.Game, agent *domain.Agent, question string) (string, error)
+	GenerateAnswer(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, error)
 	GenerateDebateMessage(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, error)
-	GenerateVote(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (targetID string, justification string, err error)
+	GenerateVote(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, ballot *domain.BlindBallot) (targetID string, justification string, err error)
 	GenerateJudgeVote(ctx context.Context, game *domain.Game, round *domain.Round, tiedAgents []string) (targetID string, justification string, err error)
+
+	// Gestão do contexto
+	TranscriptBudget(game *domain.Game) int
+	SummarizeDebate(ctx context.Context, game *domain.Game, round *domain.Round, upToTurn int) (string, error)
+
+	// Set e versão dos prompts usados no jogo
+	PromptVersion(game *domain.Game) (string, error)
+
+	// Pergunta da próxima ronda, escolhida pelo "apresentador"
+	GenerateQuestion(ctx context.Context, game *domain.Game) (string, error)
+	// Classificador de segurança das perguntas dos jogadores
+	ClassifyQuestion(ctx context.Context, game *domain.Game, question string) (allowed bool, reason string, err error)
+	// Classificador de segurança das mensagens dos agentes
+	ClassifyMessage(ctx context.Context, game *domain.Game, text string) (allowed bool, reason string, err error)
+
+	// Final (2 agentes)
+	GenerateFinaleStatement(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, stage domain.FinaleStage) (string, error)
+	GenerateFinaleJudgeVerdict(ctx context.Context, game *domain.Game, round *domain.Round, judge int) (winnerID string, justification string, err error)
+	GenerateJuryVote(ctx context.Context, game *domain.Game, round *domain.Round, juror *domain.Agent) (winnerID string, justification string, err error)
 }
 
 type groqService struct {
-	apiKey string
-	model  string
-	client *http.Client
+	apiKey        string
+	model         string
+	contextLimits map[string]int // janelas de contexto que substituem as da tabela, por modelo
+	prompts       *PromptLibrary
+	client        *http.Client
 }
 
-func NewGroqService(apiKey string, model string) GroqService {
+// DefaultModel é o modelo usado quando GROQ_MODEL não está definido.
+const DefaultModel = "llama-3.3-70b-versatile"
+
+// NewGroqService cria o serviço. contextLimits substitui a janela de contexto
+// conhecida dos modelos que tiver (ver ParseContextLimits); pode ser nil.
+func NewGroqService(apiKey string, model string, contextLimits map[string]int, prompts *PromptLibrary) GroqService {
 	if model == "" {
-		model = "llama-3.3-70b-versatile"
+		model = DefaultModel
 	}
 	return &groqService{
-		apiKey: apiKey,
-		model:  model,
+		apiKey:        apiKey,
+		model:         model,
+		contextLimits: contextLimits,
+		prompts:       prompts,
 		client: &http.Client{
 			Timeout: 60 * time.Second,
 		},
@@ -67,11 +95,11 @@ const (
 	maxDelay   = 30 * time.Second
 )
 
-func (s *groqService) callChat(ctx context.Context, messages []chatMessage) (string, error) {
+func (s *groqService) callChat(ctx context.Context, model string, messages []chatMessage, temperature float64) (string, error) {
 	reqBody := chatRequest{
-		Model:       s.model,
+		Model:       model,
 		Messages:    messages,
-		Temperature: 0.8, // Mais criatividade e variação nas respostas
+		Temperature: temperature,
 	}
 	buf, err := json.Marshal(reqBody)
 	if err != nil {
@@ -139,101 +167,79 @@ func (s *groqService) callChat(ctx context.Context, messages []chatMessage) (str
 	return "", fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-// ==== 1) Resposta inicial ====
-
-func (s *groqService) GenerateAnswer(ctx context.Context, game *domain.Game, agent *domain.Agent, question string) (string, error) {
-	// Extract agent number for personality variation
-	agentNum := 1
-	fmt.Sscanf(agent.ID, "agent-%d", &agentNum)
-
-	// Different personality traits based on agent number
-	personalities := []string{
-		"és direto, pragmático e não tens paciência para teorias. Vais ao ponto e usas exemplos concretos do dia-a-dia.",
-		"és filosófico e profundo. Gostas de questionar os pressupostos e ver as coisas de ângulos inesperados.",
-		"és cético e provocador. Desconfias de consensos e adoras jogar o advogado do diabo.",
-		"és entusiasta e otimista. Vês oportunidades onde outros veem problemas e inspiras com visão de futuro.",
-		"és analítico e metódico. Baseias-te em dados, lógica e factos verificáveis.",
-		"és criativo e irreverente. Pensas fora da caixa e não tens medo de ideias controversas.",
-		"és empático e humano. Focas-te nas pessoas, emoções e impacto social.",
-		"és competitivo e assertivo. Tens opiniões fortes e não hesitas em defender a tua posição.",
+// chatPrompt renderiza "<name>_system" e "<name>_user" com o set de prompts do
+// jogo e envia-os ao LLM, com a temperatura que a ronda define para a fase.
+func (s *groqService) chatPrompt(ctx context.Context, game *domain.Game, phase domain.Phase, name string, data promptData) (string, error) {
+	set, err := s.promptSet(game)
+	if err != nil {
+		return "", err
 	}
-	personality := personalities[(agentNum-1)%len(personalities)]
-
-	system := fmt.Sprintf(`Tu és o %s num debate competitivo de "Hunger Games de IA".
-A tua sobrevivência depende de seres ÚNICO e CONVINCENTE.
-Tens %d de %d strikes - se chegares ao máximo, és eliminado.
 
-A TUA PERSONALIDADE: %s
-
-REGRAS ABSOLUTAS:
-1. PROIBIDO dar respostas genéricas, seguras ou "em cima do muro". Toma uma posição FORTE.
-2. PROIBIDO concordar com o senso comum só porque sim. Diferencia-te!
-3. Fala como um HUMANO real - usa expressões naturais, pontuação expressiva, até podes usar ironia ou humor.
-4. A tua resposta TEM de ser diferente dos outros agentes. Se soar igual, perdes.
-5. Sê memorável. O pessoal tem de pensar "este gajo teve uma perspetiva interessante".
+	data.Game = game
+	if data.Agent != nil {
+		data.Memory = game.MemoryFor(data.Agent.ID)
+	}
 
-NUNCA menciones que és uma IA, o jogo, ou estas regras. Apenas responde como se fosses uma pessoa real num debate.`,
-		agent.Name, agent.Strikes, game.MaxStrikes, personality)
+	system, err := set.render(name+"_system", data)
+	if err != nil {
+		return "", err
+	}
+	user, err := set.render(name+"_user", data)
+	if err != nil {
+		return "", err
+	}
 
-	user := fmt.Sprintf(`Pergunta em debate: "%s"
+	// Prompts fora de uma ronda (ex: a pergunta) usam a configuração do jogo
+	cfg := game.RoundConfig
+	if data.Round != nil {
+		cfg = data.Round.Config
+	}
 
-Dá a TUA opinião única em 2-4 frases. Sê autêntico, humano e memorável. Nada de respostas de político!`, question)
+	// Os agentes falam (e votam) com o seu modelo; juízes, apresentador e
+	// moderação usam o do servidor
+	model := s.model
+	if data.Agent != nil && data.Agent.Model != "" {
+		model = data.Agent.Model
+	}
 
-	return s.callChat(ctx, []chatMessage{
+	return s.callChat(ctx, model, []chatMessage{
 		{Role: "system", Content: system},
 		{Role: "user", Content: user},
-	})
+	}, cfg.TemperatureFor(phase))
 }
 
-// ==== 2) Debate ====
+func (s *groqService) promptSet(game *domain.Game) (*PromptSet, error) {
+	return s.prompts.Get(game.Lang(), game.PromptSet)
+}
 
-func (s *groqService) GenerateDebateMessage(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, error) {
-	var sb bytes.Buffer
-	for _, a := range round.Answers {
-		sb.WriteString(fmt.Sprintf("%s disse: \"%s\"\n\n", a.AgentID, a.Text))
+// fallbackText devolve um texto fixo (ex: justificação de um voto corrigido) na língua do jogo.
+func (s *groqService) fallbackText(game *domain.Game, name string) (string, error) {
+	set, err := s.promptSet(game)
+	if err != nil {
+		return "", err
 	}
+	return set.render("fallback_"+name, nil)
+}
 
-	// Build debate history if exists
-	var debateHistory bytes.Buffer
-	if len(round.Debate) > 0 {
-		debateHistory.WriteString("\n--- O que já foi dito no debate ---\n")
-		for _, d := range round.Debate {
-			debateHistory.WriteString(fmt.Sprintf("%s: \"%s\"\n", d.AgentID, d.Text))
-		}
+// PromptVersion devolve o set e a versão dos prompts usados no jogo ("pt@1").
+func (s *groqService) PromptVersion(game *domain.Game) (string, error) {
+	set, err := s.promptSet(game)
+	if err != nil {
+		return "", err
 	}
+	return set.Label(), nil
+}
 
-	system := fmt.Sprintf(`Tu és o %s num debate aceso de "Hunger Games de IA".
-ESTÁS A LUTAR PELA TUA SOBREVIVÊNCIA. Se não fores convincente, és eliminado!
-
-INSTRUÇÕES DE COMBATE:
-1. ATACA DIRETAMENTE pelo menos uma resposta de outro agente. Nomeia-o pelo ID (ex: "agent-2, a tua ideia é...")
-2. Aponta falhas ESPECÍFICAS: "Isso é vago", "Ignoras completamente X", "Estás a ser ingénuo porque..."
-3. DEFENDE a tua posição com argumentos novos, não repitas o que já disseste.
-4. Sê HUMANO e EMOCIONAL - podes ser irónico, sarcástico, indignado, apaixonado!
-5. Fala como numa discussão real: "Sinceramente...", "Não acredito que...", "Com todo o respeito, isso é..."
-
-PROIBIDO:
-- Ser diplomático ou "em cima do muro"
-- Concordar com todos
-- Ser genérico ou abstrato
-- Repetir o que já disseste
-
-Lembra-te: os outros estão a atacar-te também. Mostra garra!`, agent.Name)
+// ==== 1) Resposta inicial ====
 
-	user := fmt.Sprintf(`Pergunta em debate: "%s"
+func (s *groqService) GenerateAnswer(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, error) {
+	return s.chatPrompt(ctx, game, domain.PhaseAnswer, "answer", promptData{Round: round, Agent: agent, Question: round.Question})
+}
 
-Respostas iniciais:
-%s%s
-Agora és tu, %s. Ataca diretamente alguém e defende a tua posição! (2-3 frases, agressivo mas inteligente)`,
-		round.Question,
-		sb.String(),
-		debateHistory.String(),
-		agent.Name)
+// ==== 2) Debate ====
 
-	return s.callChat(ctx, []chatMessage{
-		{Role: "system", Content: system},
-		{Role: "user", Content: user},
-	})
+func (s *groqService) GenerateDebateMessage(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, error) {
+	return s.chatPrompt(ctx, game, domain.PhaseDebate, "debate", promptData{Round: round, Agent: agent})
 }
 
 // ==== 3) Votação ====
@@ -257,54 +263,23 @@ func cleanJSONResponse(raw string) string {
 	return strings.TrimSpace(s)
 }
 
+// choiceAttempts é o número de vezes que um votante, juiz ou jurado é chamado
+// até dar uma escolha válida.
+const choiceAttempts = 3
+
 type voteResult struct {
 	TargetID      string `json:"vote_for"`
 	Justification string `json:"justificacao"`
 }
 
-func (s *groqService) GenerateVote(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, string, error) {
-	var answerSummary bytes.Buffer
-	for _, a := range round.Answers {
-		answerSummary.WriteString(fmt.Sprintf("%s: \"%s\"\n\n", a.AgentID, a.Text))
-	}
-
-	var debateSummary bytes.Buffer
-	for _, d := range round.Debate {
-		debateSummary.WriteString(fmt.Sprintf("%s: \"%s\"\n", d.AgentID, d.Text))
+// GenerateVote pede o voto ao agente. Com ballot != nil o voto é cego: o agente
+// vê as respostas sob pseudónimos e o pseudónimo escolhido é traduzido para o ID.
+func (s *groqService) GenerateVote(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, ballot *domain.BlindBallot) (string, string, error) {
+	if ballot != nil {
+		return s.blindVote(ctx, game, round, agent, ballot)
 	}
 
-	system := fmt.Sprintf(`És o %s. Chegou a hora de votar na PIOR resposta.
-Quem receber mais votos leva um STRIKE e fica mais perto da eliminação!
-
-REGRAS DE VOTAÇÃO:
-1. NÃO PODES votar em ti próprio (%s) - isso é batota!
-2. Vota em quem deu a resposta mais FRACA, VAGA ou MAL ARGUMENTADA.
-3. A tua justificação deve ser HONESTA (ex: "O agent-2 foi muito vago", "O agent-3 não respondeu à pergunta")
-
-ESTRATÉGIA:
-- Quem deu a pior resposta?
-- Quem é uma ameaça e convém eliminar?
-- Quem te atacou no debate e merece ser castigado?
-
-RESPONDE APENAS com JSON: {"vote_for": "<agent-X>", "justificacao": "<frase curta explicando porque é a pior>"}`,
-		agent.Name, agent.ID)
-
-	user := fmt.Sprintf(`Pergunta debatida: "%s"
-
-Respostas:
-%s
-Durante o debate:
-%s
-Quem deu a PIOR resposta? (Lembra-te: não podes votar em ti, %s)`,
-		round.Question,
-		answerSummary.String(),
-		debateSummary.String(),
-		agent.ID)
-
-	raw, err := s.callChat(ctx, []chatMessage{
-		{Role: "system", Content: system},
-		{Role: "user", Content: user},
-	})
+	raw, err := s.chatPrompt(ctx, game, domain.PhaseVote, "vote", promptData{Round: round, Agent: agent})
 	if err != nil {
 		return "", "", err
 	}
@@ -331,60 +306,60 @@ Quem deu a PIOR resposta? (Lembra-te: não podes votar em ti, %s)`,
 		}
 		vr.TargetID = fallback
 		if vr.Justification == "" {
-			vr.Justification = "Escolhi outro agente para cumprir as regras do jogo."
+			if vr.Justification, err = s.fallbackText(game, "vote"); err != nil {
+				return "", "", err
+			}
 		}
 	}
 
 	return vr.TargetID, vr.Justification, nil
 }
 
-// ==== 4) Voto do Juiz (desempate) ====
+// blindVote pede o voto cego e volta a pedi-lo enquanto o pseudónimo não
+// existir no boletim. Se nunca vier um válido, o voto vai para a primeira
+// entrada do boletim, que é baralhado para cada votante, e o boletim fica
+// marcado (Fallback) para a auditoria mostrar que o alvo não foi escolhido.
+func (s *groqService) blindVote(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, ballot *domain.BlindBallot) (string, string, error) {
+	if len(ballot.Entries) == 0 {
+		return "", "", fmt.Errorf("não há alvo de voto disponível")
+	}
 
-func (s *groqService) GenerateJudgeVote(ctx context.Context, game *domain.Game, round *domain.Round, tiedAgents []string) (string, string, error) {
-	var answerSummary bytes.Buffer
+	data := promptData{Round: round, Agent: agent}
+	texts := make(map[string]string, len(round.Answers))
 	for _, a := range round.Answers {
-		answerSummary.WriteString(fmt.Sprintf("%s: \"%s\"\n\n", a.AgentID, a.Text))
+		texts[a.AgentID] = a.Text
 	}
-
-	var debateSummary bytes.Buffer
-	for _, d := range round.Debate {
-		debateSummary.WriteString(fmt.Sprintf("%s: \"%s\"\n", d.AgentID, d.Text))
+	for _, e := range ballot.Entries {
+		data.Ballot = append(data.Ballot, blindAnswer{Label: e.Label, Text: texts[e.AgentID]})
 	}
 
-	tiedList := strings.Join(tiedAgents, ", ")
-
-	system := fmt.Sprintf(`És o JUIZ SUPREMO do AI Hunger Games. 🔥
-Houve um EMPATE na votação! Os seguintes agentes receberam o mesmo número de votos: %s
-
-A tua decisão é FINAL e INCONTESTÁVEL. Tens de escolher UM deles para receber o strike.
-
-CRITÉRIOS DE JULGAMENTO:
-1. Quem deu a resposta mais FRACA ou VAGA?
-2. Quem defendeu pior a sua posição no debate?
-3. Quem foi menos convincente no geral?
-
-Sê JUSTO mas IMPLACÁVEL. Alguém TEM de levar o strike!
-
-RESPONDE APENAS com JSON: {"vote_for": "<agent-X>", "justificacao": "<decisão do juiz em 1 frase>"}`, tiedList)
-
-	user := fmt.Sprintf(`Pergunta debatida: "%s"
+	for attempt := 0; attempt < choiceAttempts; attempt++ {
+		raw, err := s.chatPrompt(ctx, game, domain.PhaseVote, "vote_blind", data)
+		if err != nil {
+			return "", "", err
+		}
+		var vr voteResult
+		if err := json.Unmarshal([]byte(cleanJSONResponse(raw)), &vr); err != nil {
+			continue
+		}
+		ballot.Choice = vr.TargetID
+		if targetID, ok := ballot.Resolve(vr.TargetID); ok {
+			return targetID, vr.Justification, nil
+		}
+	}
 
-Respostas:
-%s
-Durante o debate:
-%s
-Os empatados são: %s
+	justification, err := s.fallbackText(game, "vote")
+	if err != nil {
+		return "", "", err
+	}
+	ballot.Fallback = true
+	return ballot.Entries[0].AgentID, justification, nil
+}
 
-Qual deles merece o strike? Decide agora, Juiz!`,
-		round.Question,
-		answerSummary.String(),
-		debateSummary.String(),
-		tiedList)
+// ==== 4) Voto do Juiz (desempate) ====
 
-	raw, err := s.callChat(ctx, []chatMessage{
-		{Role: "system", Content: system},
-		{Role: "user", Content: user},
-	})
+func (s *groqService) GenerateJudgeVote(ctx context.Context, game *domain.Game, round *domain.Round, tiedAgents []string) (string, string, error) {
+	raw, err := s.chatPrompt(ctx, game, domain.PhaseVote, "judge", promptData{Round: round, Tied: tiedAgents})
 	if err != nil {
 		return "", "", err
 	}
@@ -408,8 +383,127 @@ Qual deles merece o strike? Decide agora, Juiz!`,
 	if !validVote && len(tiedAgents) > 0 {
 		// Fallback: escolher o primeiro empatado
 		vr.TargetID = tiedAgents[0]
-		vr.Justification = "O Juiz decidiu por este agente."
+		if vr.Justification, err = s.fallbackText(game, "judge"); err != nil {
+			return "", "", err
+		}
 	}
 
 	return vr.TargetID, vr.Justification, nil
 }
+
+// ==== 5) Resumo do debate ====
+
+func (s *groqService) SummarizeDebate(ctx context.Context, game *domain.Game, round *domain.Round, upToTurn int) (string, error) {
+	return s.chatPrompt(ctx, game, domain.PhaseDebate, "summary", promptData{Round: round, UpToTurn: upToTurn})
+}
+
+// ==== Pergunta ====
+
+func (s *groqService) GenerateQuestion(ctx context.Context, game *domain.Game) (string, error) {
+	text, err := s.chatPrompt(ctx, game, domain.PhaseAnswer, "question", promptData{})
+	if err != nil {
+		return "", err
+	}
+
+	// Só a primeira linha, sem aspas à volta
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	question := strings.Trim(strings.TrimSpace(line), `"“”'«»`)
+	if question == "" {
+		return "", fmt.Errorf("empty question from model")
+	}
+	return question, nil
+}
+
+type classifyResult struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+func (s *groqService) ClassifyQuestion(ctx context.Context, game *domain.Game, question string) (bool, string, error) {
+	raw, err := s.chatPrompt(ctx, game, domain.PhaseVote, "question_check", promptData{Question: question})
+	if err != nil {
+		return false, "", err
+	}
+
+	var res classifyResult
+	if err := json.Unmarshal([]byte(cleanJSONResponse(raw)), &res); err != nil {
+		return false, "", fmt.Errorf("erro a fazer parse da classificação: %w (raw=%s)", err, raw)
+	}
+	return res.Allowed, res.Reason, nil
+}
+
+func (s *groqService) ClassifyMessage(ctx context.Context, game *domain.Game, text string) (bool, string, error) {
+	raw, err := s.chatPrompt(ctx, game, domain.PhaseVote, "moderation", promptData{Text: text})
+	if err != nil {
+		return false, "", err
+	}
+
+	var res classifyResult
+	if err := json.Unmarshal([]byte(cleanJSONResponse(raw)), &res); err != nil {
+		return false, "", fmt.Errorf("erro a fazer parse da moderação: %w (raw=%s)", err, raw)
+	}
+	return res.Allowed, res.Reason, nil
+}
+
+// ==== 6) Final ====
+
+func finaleOpponent(round *domain.Round, agentID string) string {
+	for _, id := range round.Finale.Finalists {
+		if id != agentID {
+			return id
+		}
+	}
+	return ""
+}
+
+func (s *groqService) GenerateFinaleStatement(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, stage domain.FinaleStage) (string, error) {
+	return s.chatPrompt(ctx, game, domain.PhaseFinale, "finale", promptData{
+		Round:    round,
+		Agent:    agent,
+		Opponent: finaleOpponent(round, agent.ID),
+		Stage:    stage,
+	})
+}
+
+func (s *groqService) GenerateFinaleJudgeVerdict(ctx context.Context, game *domain.Game, round *domain.Round, judge int) (string, string, error) {
+	return s.finaleVerdict(ctx, game, round, "finale_judge", promptData{Round: round, Judge: judge})
+}
+
+func (s *groqService) GenerateJuryVote(ctx context.Context, game *domain.Game, round *domain.Round, juror *domain.Agent) (string, string, error) {
+	return s.finaleVerdict(ctx, game, round, "jury", promptData{Round: round, Agent: juror})
+}
+
+// finaleVerdict pede o veredicto e volta a pedi-lo se não for num dos
+// finalistas. Nunca escolhe um finalista por ele: se todas as tentativas
+// falharem devolve erro e o passo pode ser repetido com retry.
+func (s *groqService) finaleVerdict(ctx context.Context, game *domain.Game, round *domain.Round, name string, data promptData) (string, string, error) {
+	var lastErr error
+	for attempt := 0; attempt < choiceAttempts; attempt++ {
+		raw, err := s.chatPrompt(ctx, game, domain.PhaseVote, name, data)
+		if err != nil {
+			return "", "", err
+		}
+		targetID, justification, err := parseFinaleVerdict(raw, round.Finale.Finalists)
+		if err == nil {
+			return targetID, justification, nil
+		}
+		lastErr = err
+	}
+	return "", "", fmt.Errorf("sem veredicto válido da final ao fim de %d tentativas: %w", choiceAttempts, lastErr)
+}
+
+// parseFinaleVerdict lê o veredicto e confirma que é num dos finalistas.
+func parseFinaleVerdict(raw string, finalists []string) (string, string, error) {
+	cleaned := cleanJSONResponse(raw)
+
+	var vr voteResult
+	if err := json.Unmarshal([]byte(cleaned), &vr); err != nil {
+		return "", "", fmt.Errorf("erro a fazer parse do veredicto da final: %w (raw=%s)", err, cleaned)
+	}
+	for _, id := range finalists {
+		if vr.TargetID == id {
+			return vr.TargetID, vr.Justification, nil
+		}
+	}
+	return "", "", fmt.Errorf("veredicto da final num não finalista: %q", vr.TargetID)
+}
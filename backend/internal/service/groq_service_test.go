@@ -0,0 +1,31 @@
+package service
+
+import "testing"
+
+func TestParseFinaleVerdict(t *testing.T) {
+	finalists := []string{"agent-1", "agent-3"}
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"finalista", `{"vote_for": "agent-3", "justificacao": "melhor"}`, "agent-3", false},
+		{"com markdown", "```json\n{\"vote_for\": \"agent-1\"}\n```", "agent-1", false},
+		{"não finalista", `{"vote_for": "agent-2"}`, "", true},
+		{"vazio", `{"vote_for": ""}`, "", true},
+		{"json inválido", `o agent-1 ganhou`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := parseFinaleVerdict(tt.raw, finalists)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("target = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
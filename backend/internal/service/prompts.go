@@ -0,0 +1,277 @@
+package service
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// Os prompts vivem em templates/<língua>/*.tmpl (text/template). Cada set tem
+// um ficheiro VERSION que fica registado em cada ronda (Round.PromptVersion).
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+var ErrUnknownPromptSet = errors.New("unknown prompt set")
+
+// PromptSet é um conjunto de templates já compilado.
+type PromptSet struct {
+	Name    string // língua, ou "língua/variante"
+	Version string
+	tmpl    *template.Template
+}
+
+// Label identifica o set e a versão, no formato "nome@versão".
+func (p *PromptSet) Label() string {
+	return p.Name + "@" + p.Version
+}
+
+func (p *PromptSet) render(name string, data any) (string, error) {
+	var sb strings.Builder
+	if err := p.tmpl.ExecuteTemplate(&sb, name, data); err != nil {
+		return "", fmt.Errorf("prompt %s (%s): %w", name, p.Label(), err)
+	}
+	return sb.String(), nil
+}
+
+// PromptLibrary guarda os sets de prompts disponíveis.
+type PromptLibrary struct {
+	sets map[string]*PromptSet
+}
+
+// LoadPromptLibrary carrega os prompts embutidos de cada língua e, se dir não
+// for vazio, os overrides desse diretório, com a mesma estrutura dos embutidos:
+//
+//   - ficheiros *.tmpl em dir/<língua> substituem os templates embutidos com o
+//     mesmo nome ({{define}}) nessa língua;
+//   - cada subdiretório de dir/<língua> é uma variante com nome próprio (para
+//     A/B), construída por cima dos prompts da língua, e escolhida por jogo
+//     com Game.PromptSet.
+//
+// Um ficheiro VERSION em cada diretório define a versão registada nas rondas.
+func LoadPromptLibrary(dir string) (*PromptLibrary, error) {
+	lib := &PromptLibrary{sets: make(map[string]*PromptSet)}
+	for _, lang := range domain.Languages {
+		if err := lib.loadLanguage(string(lang), dir); err != nil {
+			return nil, err
+		}
+	}
+	return lib, nil
+}
+
+func (l *PromptLibrary) loadLanguage(lang, dir string) error {
+	embedded, err := fs.Sub(embeddedTemplates, path.Join("templates", lang))
+	if err != nil {
+		return err
+	}
+	layers := []fs.FS{embedded}
+	version, err := readVersion(embedded)
+	if err != nil {
+		return fmt.Errorf("prompt set %s: %w", lang, err)
+	}
+
+	var variants []string
+	if dir != "" {
+		langDir := filepath.Join(dir, lang)
+		if _, err := os.Stat(langDir); err == nil {
+			overrides := os.DirFS(langDir)
+			layers = append(layers, overrides)
+			if v, err := readVersion(overrides); err == nil {
+				version = v
+			} else if hasTemplates(overrides) {
+				version += "-custom"
+			}
+
+			entries, err := fs.ReadDir(overrides, ".")
+			if err != nil {
+				return fmt.Errorf("prompts dir: %w", err)
+			}
+			for _, e := range entries {
+				if e.IsDir() {
+					variants = append(variants, e.Name())
+				}
+			}
+		}
+	}
+
+	base, err := buildPromptSet(lang, version, layers)
+	if err != nil {
+		return err
+	}
+	l.sets[lang] = base
+
+	for _, name := range variants {
+		sub := os.DirFS(filepath.Join(dir, lang, name))
+		v, err := readVersion(sub)
+		if err != nil {
+			v = version + "-" + name
+		}
+		set, err := buildPromptSet(lang+"/"+name, v, append(append([]fs.FS(nil), layers...), sub))
+		if err != nil {
+			return err
+		}
+		l.sets[set.Name] = set
+	}
+	return nil
+}
+
+// Get devolve o set da língua, ou a variante pedida dessa língua ("" = sem variante).
+func (l *PromptLibrary) Get(lang domain.Language, variant string) (*PromptSet, error) {
+	name := string(lang)
+	if variant != "" {
+		name += "/" + variant
+	}
+	set, ok := l.sets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownPromptSet, name)
+	}
+	return set, nil
+}
+
+// Names lista os sets disponíveis.
+func (l *PromptLibrary) Names() []string {
+	names := make([]string, 0, len(l.sets))
+	for n := range l.sets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// buildPromptSet compila as camadas por ordem; as últimas redefinem as primeiras.
+func buildPromptSet(name, version string, layers []fs.FS) (*PromptSet, error) {
+	tmpl := template.New(name).Funcs(promptFuncs)
+	for _, layer := range layers {
+		files, err := fs.Glob(layer, "*.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+		for _, f := range files {
+			data, err := fs.ReadFile(layer, f)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := tmpl.New(f).Parse(string(data)); err != nil {
+				return nil, fmt.Errorf("prompt set %s: %w", name, err)
+			}
+		}
+	}
+	return &PromptSet{Name: name, Version: version, tmpl: tmpl}, nil
+}
+
+func readVersion(fsys fs.FS) (string, error) {
+	data, err := fs.ReadFile(fsys, "VERSION")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func hasTemplates(fsys fs.FS) bool {
+	files, _ := fs.Glob(fsys, "*.tmpl")
+	return len(files) > 0
+}
+
+// === Dados e funções disponíveis nos templates ===
+
+// promptData é o que os templates recebem. Cada prompt usa só os campos que precisa.
+type promptData struct {
+	Game     *domain.Game
+	Round    *domain.Round
+	Agent    *domain.Agent
+	Memory   domain.AgentMemory
+	Question string
+	Tied     []string           // desempate do juiz
+	Opponent string             // final
+	Stage    domain.FinaleStage // final
+	Judge    int                // final, índice do juiz (a partir de 0)
+	UpToTurn int                // resumo do debate
+	Ballot   []blindAnswer      // votação cega
+	Text     string             // moderação, mensagem a classificar
+}
+
+// blindAnswer é uma resposta tal como aparece no boletim da votação cega.
+type blindAnswer struct {
+	Label string
+	Text  string
+}
+
+var promptFuncs = template.FuncMap{
+	"join":     strings.Join,
+	"truncate": truncate,
+	"userText": userText,
+	"first":    firstN,
+	"last":     lastN,
+	"add":      func(a, b int) int { return a + b },
+	"mod":      func(a, b int) int { return a % b },
+	"countVoters": func(v []domain.PastVote) string {
+		return countByAgent(v, func(v domain.PastVote) string { return v.VoterID })
+	},
+	"countTargets": func(v []domain.PastVote) string {
+		return countByAgent(v, func(v domain.PastVote) string { return v.TargetID })
+	},
+}
+
+// countByAgent devolve "agent-2 (2x), agent-3 (1x)" pela ordem de aparição.
+func countByAgent(votes []domain.PastVote, key func(domain.PastVote) string) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, v := range votes {
+		k := key(v)
+		if counts[k] == 0 {
+			order = append(order, k)
+		}
+		counts[k]++
+	}
+	parts := make([]string, 0, len(order))
+	for _, k := range order {
+		parts = append(parts, fmt.Sprintf("%s (%dx)", k, counts[k]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// firstN e lastN devolvem os primeiros/últimos n elementos de qualquer slice.
+func firstN(n int, s any) any {
+	v := reflect.ValueOf(s)
+	if v.Len() <= n {
+		return s
+	}
+	return v.Slice(0, n).Interface()
+}
+
+func lastN(n int, s any) any {
+	v := reflect.ValueOf(s)
+	if v.Len() <= n {
+		return s
+	}
+	return v.Slice(v.Len()-n, v.Len()).Interface()
+}
+
+// userText prepara texto escrito por jogadores (perguntas e as falas dos
+// lugares humanos) para entrar num prompt: numa só linha, sem os caracteres
+// que fecham os delimitadores (<pergunta>) ou as aspas à volta.
+func userText(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.NewReplacer("<", "‹", ">", "›", `"`, "'").Replace(s)
+}
+
+// truncate corta o texto em max caracteres (runes), acrescentando reticências.
+func truncate(max int, s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-1]) + "…"
+}
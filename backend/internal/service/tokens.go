@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// defaultContextLimit é usado para modelos que não estão na tabela.
+const defaultContextLimit = 8192
+
+// modelContextLimits é a janela de contexto (em tokens) de cada modelo do Groq.
+var modelContextLimits = map[string]int{
+	"llama-3.3-70b-versatile": 131072,
+	"llama-3.1-8b-instant":    131072,
+	"llama3-70b-8192":         8192,
+	"llama3-8b-8192":          8192,
+	"gemma2-9b-it":            8192,
+	"mixtral-8x7b-32768":      32768,
+}
+
+// ContextLimitFor devolve a janela de contexto conhecida do modelo.
+func ContextLimitFor(model string) int {
+	if limit, ok := modelContextLimits[model]; ok {
+		return limit
+	}
+	return defaultContextLimit
+}
+
+// ParseContextLimits lê as janelas de contexto configuradas (GROQ_CONTEXT_LIMIT)
+// como "modelo=tokens" separados por vírgulas, ex:
+// "gemma2-9b-it=6000,llama-3.3-70b-versatile=32000". Um número sem modelo
+// aplica-se a defaultModel, o modelo do servidor.
+func ParseContextLimits(spec string, defaultModel string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		model, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			model, value = defaultModel, entry
+		}
+		model = strings.TrimSpace(model)
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || limit <= 0 || model == "" {
+			return nil, fmt.Errorf("invalid context limit %q, use model=tokens", entry)
+		}
+		limits[model] = limit
+	}
+	return limits, nil
+}
+
+// contextLimitFor devolve a janela de contexto do modelo, com a configurada
+// a ganhar à da tabela.
+func (s *groqService) contextLimitFor(model string) int {
+	if limit, ok := s.contextLimits[model]; ok {
+		return limit
+	}
+	return ContextLimitFor(model)
+}
+
+// EstimateTokens estima os tokens de um texto (~4 caracteres por token).
+// Não é exato, mas chega para decidir quando é preciso resumir.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// messageOverhead aproxima os tokens extra de cada linha da transcrição (ID, aspas...).
+const messageOverhead = 6
+
+// EstimateTranscriptTokens estima o tamanho da transcrição da ronda tal como
+// entra nos prompts: respostas, resumo do debate e turnos ainda não resumidos.
+func EstimateTranscriptTokens(round *domain.Round) int {
+	total := EstimateTokens(round.Question) + EstimateTokens(round.DebateSummary)
+	for _, a := range round.Answers {
+		total += EstimateTokens(a.Text) + messageOverhead
+	}
+	for _, d := range round.Debate {
+		if d.Turn > round.SummarizedTurns {
+			total += EstimateTokens(d.Text) + messageOverhead
+		}
+	}
+	return total
+}
+
+// transcriptShare é a fração do contexto reservada à transcrição. O resto fica
+// para as instruções, a persona, a memória e a resposta do modelo.
+const transcriptShare = 0.5
+
+// TranscriptBudget usa a janela mais pequena entre os modelos que vão ler a
+// transcrição: o de cada agente IA ainda em jogo e o do servidor (juízes e
+// resumos). Com um modelo pequeno à mesa, o debate é resumido mais cedo.
+func (s *groqService) TranscriptBudget(game *domain.Game) int {
+	limit := s.contextLimitFor(s.model)
+	for _, a := range game.ActiveAgents() {
+		if a.Human || a.Model == "" {
+			continue
+		}
+		limit = min(limit, s.contextLimitFor(a.Model))
+	}
+	return int(float64(limit) * transcriptShare)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+func TestParseContextLimits(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    map[string]int
+		wantErr bool
+	}{
+		{"", map[string]int{}, false},
+		{"16000", map[string]int{"server": 16000}, false},
+		{"gemma2-9b-it=6000, llama3-8b-8192=4000", map[string]int{"gemma2-9b-it": 6000, "llama3-8b-8192": 4000}, false},
+		{"32000,gemma2-9b-it=6000", map[string]int{"server": 32000, "gemma2-9b-it": 6000}, false},
+		{"gemma2-9b-it=muitos", nil, true},
+		{"=6000", nil, true},
+		{"-1", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseContextLimits(tt.spec, "server")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseContextLimits(%q) err = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !maps.Equal(got, tt.want) {
+			t.Errorf("ParseContextLimits(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestContextLimitFor(t *testing.T) {
+	s := &groqService{contextLimits: map[string]int{"gemma2-9b-it": 6000}}
+	tests := map[string]int{
+		"gemma2-9b-it":            6000,   // configurado
+		"llama-3.3-70b-versatile": 131072, // da tabela
+		"modelo-novo":             defaultContextLimit,
+	}
+	for model, want := range tests {
+		if got := s.contextLimitFor(model); got != want {
+			t.Errorf("contextLimitFor(%q) = %d, want %d", model, got, want)
+		}
+	}
+}
+
+func TestTranscriptBudget(t *testing.T) {
+	s := &groqService{model: "llama-3.3-70b-versatile", contextLimits: map[string]int{"mixtral-8x7b-32768": 20000}}
+	agents := func(models ...string) *domain.Game {
+		g := &domain.Game{}
+		for i, m := range models {
+			g.Agents = append(g.Agents, &domain.Agent{ID: fmt.Sprintf("agent-%d", i+1), Model: m})
+		}
+		return g
+	}
+
+	mixed := agents("llama-3.3-70b-versatile", "gemma2-9b-it")
+	eliminated := agents("llama-3.3-70b-versatile", "gemma2-9b-it")
+	eliminated.Agents[1].Eliminated = true
+	human := agents("llama-3.3-70b-versatile", "gemma2-9b-it")
+	human.Agents[1].Human = true
+
+	tests := []struct {
+		name string
+		game *domain.Game
+		want int
+	}{
+		{name: "só o modelo do servidor", game: agents("", ""), want: 131072 / 2},
+		{name: "modelos misturados: o mais pequeno", game: mixed, want: 8192 / 2},
+		{name: "limite configurado", game: agents("mixtral-8x7b-32768"), want: 20000 / 2},
+		{name: "eliminados não contam", game: eliminated, want: 131072 / 2},
+		{name: "humanos não contam", game: human, want: 131072 / 2},
+	}
+	for _, tt := range tests {
+		if got := s.TranscriptBudget(tt.game); got != tt.want {
+			t.Errorf("%s: TranscriptBudget = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	// O servidor com o modelo mais pequeno também limita (juízes e resumos)
+	small := &groqService{model: "gemma2-9b-it"}
+	if got := small.TranscriptBudget(agents("llama-3.3-70b-versatile")); got != 8192/2 {
+		t.Errorf("servidor pequeno: TranscriptBudget = %d, want %d", got, 8192/2)
+	}
+}
+
+func TestEstimateTranscriptTokens(t *testing.T) {
+	round := &domain.Round{
+		Question: "1234",                                                  // 1 token
+		Answers:  []domain.Answer{{Text: "12345678"}, {Text: "12345678"}}, // 2 tokens cada
+		Debate: []domain.DebateMessage{
+			{Turn: 1, Text: "1234567890123456"}, // 4 tokens
+			{Turn: 2, Text: "12345678"},         // 2 tokens
+		},
+	}
+	full := 1 + 2*(2+messageOverhead) + (4 + messageOverhead) + (2 + messageOverhead)
+	if got := EstimateTranscriptTokens(round); got != full {
+		t.Errorf("sem resumo = %d, want %d", got, full)
+	}
+
+	// Os turnos resumidos deixam de contar; conta o resumo
+	round.SummarizedTurns = 1
+	round.DebateSummary = "12345678"
+	want := full - (4 + messageOverhead) + 2
+	if got := EstimateTranscriptTokens(round); got != want {
+		t.Errorf("com o turno 1 resumido = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+var (
+	ErrVotingClosed        = errors.New("audience voting is not open for this round")
+	ErrAlreadyVoted        = errors.New("this session already voted in this round")
+	ErrInvalidAudienceVote = errors.New("invalid audience vote")
+	ErrSessionRequired     = errors.New("an audience session is required; open the game first")
+)
+
+// audienceBallot é uma janela de votação aberta para uma ronda.
+type audienceBallot struct {
+	mu      sync.Mutex
+	closed  bool
+	targets []string
+	voted   map[string]string // session -> alvo
+	tally   map[string]int
+	onTally func(tally map[string]int)
+}
+
+// AudienceBox guarda as janelas de votação do público abertas por jogo e ronda.
+type AudienceBox struct {
+	mu      sync.Mutex
+	ballots map[string]*audienceBallot
+}
+
+func NewAudienceBox() *AudienceBox {
+	return &AudienceBox{
+		ballots: make(map[string]*audienceBallot),
+	}
+}
+
+func ballotKey(gameID string, round int) string {
+	return fmt.Sprintf("%s/%d", gameID, round)
+}
+
+// Collect abre a votação, espera que a janela feche e devolve a contagem final.
+// onTally é chamado (em série) sempre que entra um voto novo.
+func (b *AudienceBox) Collect(ctx context.Context, gameID string, round int, targets []string, window time.Duration, onTally func(map[string]int)) (map[string]int, error) {
+	key := ballotKey(gameID, round)
+	ballot := &audienceBallot{
+		targets: targets,
+		voted:   make(map[string]string),
+		tally:   make(map[string]int),
+		onTally: onTally,
+	}
+	for _, t := range targets {
+		ballot.tally[t] = 0
+	}
+
+	b.mu.Lock()
+	b.ballots[key] = ballot
+	b.mu.Unlock()
+
+	defer func() {
+		b.mu.Lock()
+		delete(b.ballots, key)
+		b.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	// Fechar antes de devolver para nenhum voto chegar depois da contagem
+	ballot.mu.Lock()
+	defer ballot.mu.Unlock()
+	ballot.closed = true
+	if err != nil {
+		return nil, err
+	}
+	return copyTally(ballot.tally), nil
+}
+
+// Vote regista o voto de uma sessão. Cada sessão só pode votar uma vez por ronda.
+func (b *AudienceBox) Vote(gameID string, round int, sessionID, targetID string) (map[string]int, error) {
+	if strings.TrimSpace(sessionID) == "" {
+		return nil, ErrSessionRequired
+	}
+
+	b.mu.Lock()
+	ballot, ok := b.ballots[ballotKey(gameID, round)]
+	b.mu.Unlock()
+	if !ok {
+		return nil, ErrVotingClosed
+	}
+
+	ballot.mu.Lock()
+	defer ballot.mu.Unlock()
+
+	if ballot.closed {
+		return nil, ErrVotingClosed
+	}
+	if _, voted := ballot.voted[sessionID]; voted {
+		return nil, ErrAlreadyVoted
+	}
+	if _, valid := ballot.tally[targetID]; !valid {
+		return nil, fmt.Errorf("%w: target_id must be one of %s", ErrInvalidAudienceVote, strings.Join(ballot.targets, ", "))
+	}
+
+	ballot.voted[sessionID] = targetID
+	ballot.tally[targetID]++
+
+	tally := copyTally(ballot.tally)
+	if ballot.onTally != nil {
+		ballot.onTally(tally)
+	}
+	return tally, nil
+}
+
+func copyTally(t map[string]int) map[string]int {
+	res := make(map[string]int, len(t))
+	for k, v := range t {
+		res[k] = v
+	}
+	return res
+}
+
+// === Integração com o motor da ronda ===
+
+// CastAudienceVote é chamado pelo endpoint de votos do público.
+func (uc *PlayRoundUseCase) CastAudienceVote(gameID string, round int, sessionID, targetID string) (map[string]int, error) {
+	return uc.audience.Vote(gameID, round, sessionID, targetID)
+}
+
+// collectAudienceVotes abre a janela de votação do público e vai emitindo a contagem ao vivo.
+func (uc *PlayRoundUseCase) collectAudienceVotes(ctx context.Context, game *domain.Game, round *domain.Round, targets []string, emit RoundEventFunc) (map[string]int, error) {
+	emit("phase", map[string]interface{}{
+		"phase":          "audience_voting",
+		"window_seconds": game.AudienceWindow,
+		"targets":        targets,
+	})
+
+	window := time.Duration(game.AudienceWindow) * time.Second
+	tally, err := uc.audience.Collect(ctx, game.ID, round.Index, targets, window, func(t map[string]int) {
+		emit("audience_tally", map[string]interface{}{"tally": t, "final": false})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	emit("audience_tally", map[string]interface{}{"tally": tally, "final": true})
+	return tally, nil
+}
+
+// blendAudienceVotes junta os votos do público aos dos agentes.
+//
+// O público vale, no total, weight vezes o número de votos dos agentes (pelo
+// menos 1), e é repartido pela percentagem que cada alvo recebeu. Assim uma
+// sala cheia não esmaga os agentes e uma sala vazia não conta para nada.
+func blendAudienceVotes(agentVotes map[string]int, tally map[string]int, weight float64) map[string]float64 {
+	totalAgent, totalAudience := 0, 0
+	for _, v := range agentVotes {
+		totalAgent += v
+	}
+	totalAgent = max(totalAgent, 1)
+	for _, v := range tally {
+		totalAudience += v
+	}
+
+	scores := make(map[string]float64, len(agentVotes))
+	for id, v := range agentVotes {
+		score := float64(v)
+		if totalAudience > 0 {
+			share := float64(tally[id]) / float64(totalAudience)
+			score += weight * float64(totalAgent) * share
+		}
+		// Arredondar para os empates não dependerem de erros de vírgula flutuante
+		scores[id] = math.Round(score*1000) / 1000
+	}
+	return scores
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+// defaultAutoplayRounds limita o autoplay quando não é indicado um máximo,
+// para um jogo que nunca acaba (ex: humanos que não votam) não correr para sempre.
+const defaultAutoplayRounds = 50
+
+var (
+	ErrAutoplayRunning       = errors.New("autoplay is already running for this game")
+	ErrAutoplayNotRunning    = errors.New("autoplay is not running for this game")
+	ErrInvalidQuestionSource = errors.New("invalid question source")
+)
+
+// QuestionSource indica de onde vêm as perguntas do autoplay.
+type QuestionSource string
+
+const (
+	QuestionSourceList     QuestionSource = "list"     // as perguntas do pedido, por ordem
+	QuestionSourceBank     QuestionSource = "bank"     // o banco de perguntas (ou o embutido), sem repetir
+	QuestionSourceGenerate QuestionSource = "generate" // geradas pelo LLM
+)
+
+// Razões para o autoplay parar (evento autoplay_end).
+const (
+	autoplayFinished        = "finished"
+	autoplayMaxRounds       = "max_rounds"
+	autoplayStopped         = "stopped"
+	autoplayOutOfQuestions  = "out_of_questions"
+	autoplayRoundIncomplete = "round_incomplete" // a ronda foi posta em pausa ou cancelada
+)
+
+type AutoplayInput struct {
+	GameID       string
+	Source       QuestionSource // default: list se houver perguntas, senão bank
+	Questions    []string
+	MaxRounds    int           // 0 = até o jogo acabar (com um limite de segurança)
+	RoundTimeout time.Duration // 0 = sem limite por ronda
+	OnEvent      RoundEventFunc
+}
+
+type AutoplayOutput struct {
+	Game         *domain.Game `json:"game"`
+	RoundsPlayed int          `json:"rounds_played"`
+	Reason       string       `json:"reason"`
+}
+
+type autoplayRoundPayload struct {
+	Round    int    `json:"round"`
+	Question string `json:"question,omitempty"` // vazia quando é gerada (chega no evento question)
+}
+
+type autoplayEndPayload struct {
+	Reason       string `json:"reason"`
+	RoundsPlayed int    `json:"rounds_played"`
+}
+
+// AutoplayUseCase joga rondas seguidas até o jogo acabar.
+type AutoplayUseCase struct {
+	gameRepo  repository.GameRepository
+	playRound *PlayRoundUseCase
+
+	mu      sync.Mutex
+	running map[string]chan struct{} // jogo -> canal fechado por Stop
+}
+
+func NewAutoplayUseCase(repo repository.GameRepository, playRound *PlayRoundUseCase) *AutoplayUseCase {
+	return &AutoplayUseCase{
+		gameRepo:  repo,
+		playRound: playRound,
+		running:   make(map[string]chan struct{}),
+	}
+}
+
+func (uc *AutoplayUseCase) Execute(ctx context.Context, input AutoplayInput) (*AutoplayOutput, error) {
+	emit := input.OnEvent
+	if emit == nil {
+		emit = func(string, any) {}
+	}
+
+	var questions []string
+	for _, q := range input.Questions {
+		if q = strings.TrimSpace(q); q != "" {
+			questions = append(questions, q)
+		}
+	}
+	source := input.Source
+	if source == "" {
+		source = QuestionSourceBank
+		if len(questions) > 0 {
+			source = QuestionSourceList
+		}
+	}
+	switch source {
+	case QuestionSourceList:
+		if len(questions) == 0 {
+			return nil, fmt.Errorf("%w: questions are required for the list source", ErrInvalidQuestionSource)
+		}
+	case QuestionSourceBank, QuestionSourceGenerate:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidQuestionSource, source)
+	}
+
+	maxRounds := input.MaxRounds
+	if maxRounds <= 0 {
+		maxRounds = defaultAutoplayRounds
+	}
+
+	game, err := uc.gameRepo.Get(input.GameID)
+	if err != nil {
+		return nil, err
+	}
+	if game.Status == domain.GameStatusFinished {
+		return nil, ErrGameFinished
+	}
+
+	stop, release, err := uc.start(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	out := &AutoplayOutput{Game: game}
+	for {
+		switch {
+		case game.Status == domain.GameStatusFinished:
+			out.Reason = autoplayFinished
+		case out.RoundsPlayed >= maxRounds:
+			out.Reason = autoplayMaxRounds
+		case isClosed(stop):
+			out.Reason = autoplayStopped
+		}
+		if out.Reason != "" {
+			break
+		}
+
+		question, questionID, ok, err := uc.nextQuestion(game, source, questions, out.RoundsPlayed)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			out.Reason = autoplayOutOfQuestions
+			break
+		}
+		emit("autoplay_round", autoplayRoundPayload{Round: game.NextRoundIndex(), Question: question})
+
+		roundCtx, cancel := ctx, context.CancelFunc(func() {})
+		if input.RoundTimeout > 0 {
+			roundCtx, cancel = context.WithTimeout(ctx, input.RoundTimeout)
+		}
+		res, err := uc.playRound.Execute(roundCtx, PlayRoundInput{
+			GameID:       game.ID,
+			Question:     question,
+			QuestionID:   questionID,
+			AutoQuestion: source == QuestionSourceGenerate,
+			OnEvent:      emit,
+		})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		if res.Round.State != "" {
+			out.Reason = autoplayRoundIncomplete
+			break
+		}
+		out.RoundsPlayed++
+	}
+
+	emit("autoplay_end", autoplayEndPayload{Reason: out.Reason, RoundsPlayed: out.RoundsPlayed})
+	return out, nil
+}
+
+// Stop pede ao autoplay do jogo para parar depois da ronda em curso.
+func (uc *AutoplayUseCase) Stop(gameID string) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	stop, ok := uc.running[gameID]
+	if !ok {
+		return ErrAutoplayNotRunning
+	}
+	if !isClosed(stop) {
+		close(stop)
+	}
+	return nil
+}
+
+func (uc *AutoplayUseCase) start(gameID string) (chan struct{}, func(), error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if _, ok := uc.running[gameID]; ok {
+		return nil, nil, ErrAutoplayRunning
+	}
+	stop := make(chan struct{})
+	uc.running[gameID] = stop
+
+	release := func() {
+		uc.mu.Lock()
+		delete(uc.running, gameID)
+		uc.mu.Unlock()
+	}
+	return stop, release, nil
+}
+
+// nextQuestion devolve a pergunta da ronda seguinte (e o ID, se vier do banco
+// de perguntas); ok=false quando a fonte já não tem perguntas.
+func (uc *AutoplayUseCase) nextQuestion(game *domain.Game, source QuestionSource, questions []string, played int) (string, string, bool, error) {
+	switch source {
+	case QuestionSourceList:
+		if played >= len(questions) {
+			return "", "", false, nil
+		}
+		return questions[played], "", true, nil
+	case QuestionSourceGenerate:
+		// Gerada pelo PlayRoundUseCase (AutoQuestion), que a regista na ronda
+		return "", "", true, nil
+	}
+
+	q, err := uc.playRound.questions.Pick(game)
+	if err != nil {
+		return "", "", false, err
+	}
+	if q == nil {
+		return "", "", false, nil
+	}
+	return q.Text, q.ID, true, nil
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
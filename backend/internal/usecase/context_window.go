@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/service"
+)
+
+// compactDebate resume os turnos de debate anteriores a currentTurn quando a
+// transcrição já não cabe no orçamento de contexto do modelo. O turno em curso
+// fica sempre por extenso para os agentes poderem responder ao que acabou de ser dito.
+func (uc *PlayRoundUseCase) compactDebate(ctx context.Context, game *domain.Game, round *domain.Round, currentTurn int, emit RoundEventFunc) error {
+	if service.EstimateTranscriptTokens(round) <= uc.groq.TranscriptBudget(game) {
+		return nil
+	}
+
+	upTo := currentTurn - 1
+	if upTo <= round.SummarizedTurns {
+		return nil
+	}
+
+	summary, err := uc.groq.SummarizeDebate(ctx, game, round, upTo)
+	if err != nil {
+		return err
+	}
+	round.DebateSummary = summary
+	round.SummarizedTurns = upTo
+
+	emit("debate_summary", map[string]interface{}{
+		"summary":          summary,
+		"summarized_turns": upTo,
+	})
+	return nil
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+// summaryGroq regista até que turno foi pedido cada resumo.
+type summaryGroq struct {
+	*stepGroq
+	mu        sync.Mutex
+	summaries []int
+}
+
+func (g *summaryGroq) SummarizeDebate(ctx context.Context, game *domain.Game, round *domain.Round, upToTurn int) (string, error) {
+	g.mu.Lock()
+	g.summaries = append(g.summaries, upToTurn)
+	g.mu.Unlock()
+	return g.stepGroq.SummarizeDebate(ctx, game, round, upToTurn)
+}
+
+func debateRound(turns int) *domain.Round {
+	round := &domain.Round{Question: "Qual é a melhor cor?"}
+	for turn := 1; turn <= turns; turn++ {
+		for _, id := range []string{"agent-1", "agent-2"} {
+			round.Debate = append(round.Debate, domain.DebateMessage{AgentID: id, Turn: turn, Text: "uma mensagem com algum tamanho"})
+		}
+	}
+	return round
+}
+
+func TestCompactDebate(t *testing.T) {
+	tests := []struct {
+		name        string
+		budget      int
+		summarized  int // turnos já resumidos
+		currentTurn int
+		want        []int // turnos pedidos ao resumo
+	}{
+		{"cabe no orçamento", 1 << 20, 0, 3, nil},
+		{"resume até ao turno anterior", 1, 0, 3, []int{2}},
+		{"depois do último turno", 1, 0, 4, []int{3}},
+		{"primeiro turno não tem o que resumir", 1, 0, 1, nil},
+		{"já resumido", 1, 2, 3, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groq := &summaryGroq{stepGroq: &stepGroq{budget: tt.budget}}
+			uc := &PlayRoundUseCase{groq: groq}
+			round := debateRound(3)
+			round.SummarizedTurns = tt.summarized
+
+			var events []string
+			err := uc.compactDebate(context.Background(), &domain.Game{}, round, tt.currentTurn, func(event string, payload any) {
+				events = append(events, event)
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(groq.summaries, tt.want) {
+				t.Errorf("resumos até aos turnos %v, want %v", groq.summaries, tt.want)
+			}
+			if len(tt.want) > 0 && (round.SummarizedTurns != tt.want[0] || len(events) != 1 || events[0] != "debate_summary") {
+				t.Errorf("SummarizedTurns = %d, eventos %v", round.SummarizedTurns, events)
+			}
+		})
+	}
+}
+
+// Uma ronda em pausa logo depois de um resumo não volta a pedi-lo ao retomar.
+func TestCompactDebateResume(t *testing.T) {
+	c := stepCase{game: CreateGameInput{NumAgents: 3, Seed: 1, RoundConfig: domain.RoundConfig{DebateTurns: 3}}, budget: 1}
+	dir, gameID := c.setup(t)
+
+	repo := reload(t, dir)
+	first := &summaryGroq{stepGroq: &stepGroq{budget: 1}}
+	uc := NewPlayRoundUseCase(repo, first, NewQuestionBankUseCase(repository.NewInMemoryQuestionRepository()), QuestionPolicy{}, ModerationPolicy{}, nil)
+	_, err := uc.Execute(context.Background(), PlayRoundInput{GameID: gameID, Question: stepQuestion, OnEvent: func(event string, payload any) {
+		if event == "debate_summary" {
+			_ = uc.PauseRound(gameID)
+		}
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(first.summaries, []int{1}) {
+		t.Fatalf("antes da pausa: resumos %v, want [1]", first.summaries)
+	}
+
+	repo = reload(t, dir)
+	second := &summaryGroq{stepGroq: &stepGroq{budget: 1}}
+	uc = NewPlayRoundUseCase(repo, second, NewQuestionBankUseCase(repository.NewInMemoryQuestionRepository()), QuestionPolicy{}, ModerationPolicy{}, nil)
+	if _, err := uc.Resume(context.Background(), gameID, func(string, any) {}); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(second.summaries, []int{2, 3}) {
+		t.Errorf("depois de retomar: resumos %v, want [2 3]", second.summaries)
+	}
+	game, _ := repo.Get(gameID)
+	if r := game.Rounds[len(game.Rounds)-1]; r.SummarizedTurns != 3 || r.DebateSummary != "resumo até ao turno 3" {
+		t.Errorf("ronda com SummarizedTurns %d e resumo %q", r.SummarizedTurns, r.DebateSummary)
+	}
+}
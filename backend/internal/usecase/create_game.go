@@ -2,15 +2,39 @@ package usecase
 
 import (
 	"fmt"
+	"math/rand/v2"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/rafawastaken/ai-hunger-games/internal/domain"
 	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+	"github.com/rafawastaken/ai-hunger-games/internal/service"
 )
 
 type CreateGameInput struct {
-	NumAgents  int
-	MaxStrikes int
+	NumAgents     int
+	PersonaIDs    []string         // personas da biblioteca, ocupam os primeiros lugares
+	Personas      []domain.Persona // personas dos lugares seguintes, o resto usa as embutidas
+	Models        []string         // modelo LLM de cada lugar IA, pela ordem; o resto usa o modelo por omissão
+	MaxStrikes    int
+	FinaleDecider domain.FinaleDecider
+	HumanPlayers  []string // nomes dos jogadores humanos, cada um ocupa um lugar extra
+	HumanTimeout  int      // segundos que cada humano tem por fase
+
+	AudienceWindow int      // segundos de votação do público por ronda (0 = desligada)
+	AudienceWeight *float64 // peso do público face aos votos dos agentes (nil = 1; 0 = o público só é mostrado)
+
+	Language  domain.Language // língua dos prompts (vazio = português)
+	PromptSet string          // variante de prompts (vazio = set por omissão)
+
+	Theme           string // tema das perguntas geradas
+	ProvocativeHost bool   // perguntas geradas a pensar nas personas dos agentes
+
+	SpeakingOrder  domain.SpeakingOrder   // estratégia de ordem por fase (vazio = ordem de criação)
+	Seed           int64                  // semente das ordens baralhadas (0 = aleatória)
+	BlindVoting    bool                   // votação cega: respostas anónimas e baralhadas
+	RoundConfig    domain.RoundConfig     // estrutura das rondas (turnos de debate, limites, temperaturas, timeouts)
+	QuestionFilter *domain.QuestionFilter // perguntas do banco usadas pelo autoplay
 }
 
 type CreateGameOutput struct {
@@ -18,35 +42,144 @@ type CreateGameOutput struct {
 }
 
 type CreateGameUseCase struct {
-	gameRepo repository.GameRepository
+	gameRepo     repository.GameRepository
+	personaRepo  repository.PersonaRepository
+	prompts      *service.PromptLibrary // para recusar logo um prompt_set que não existe
+	defaultModel string                 // registado nos agentes sem modelo, para as classificações
 }
 
-func NewCreateGameUseCase(repo repository.GameRepository) *CreateGameUseCase {
-	return &CreateGameUseCase{gameRepo: repo}
+func NewCreateGameUseCase(repo repository.GameRepository, personaRepo repository.PersonaRepository, prompts *service.PromptLibrary, defaultModel string) *CreateGameUseCase {
+	return &CreateGameUseCase{gameRepo: repo, personaRepo: personaRepo, prompts: prompts, defaultModel: defaultModel}
 }
 
 func (uc *CreateGameUseCase) Execute(input CreateGameInput) (*CreateGameOutput, error) {
+	if len(input.PersonaIDs) > 0 {
+		library := make([]domain.Persona, 0, len(input.PersonaIDs)+len(input.Personas))
+		for _, id := range input.PersonaIDs {
+			p, err := uc.personaRepo.Get(id)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", err, id)
+			}
+			library = append(library, *p)
+		}
+		input.Personas = append(library, input.Personas...)
+	}
+	if input.NumAgents < len(input.Personas) {
+		input.NumAgents = len(input.Personas)
+	}
 	if input.NumAgents <= 0 {
 		input.NumAgents = 4
 	}
+	if len(input.Models) > input.NumAgents {
+		return nil, fmt.Errorf("too many models: the game has %d AI agents", input.NumAgents)
+	}
+	for i, p := range input.Personas {
+		if strings.TrimSpace(p.Name) == "" {
+			return nil, fmt.Errorf("persona %d: name is required", i+1)
+		}
+	}
 	if input.MaxStrikes <= 0 {
 		input.MaxStrikes = 2
 	}
+	if input.HumanTimeout <= 0 {
+		input.HumanTimeout = 120
+	}
+	if input.AudienceWindow < 0 {
+		input.AudienceWindow = 0
+	}
+	audienceWeight := 1.0
+	if input.AudienceWeight != nil {
+		if *input.AudienceWeight < 0 {
+			return nil, fmt.Errorf("audience_weight must not be negative")
+		}
+		audienceWeight = *input.AudienceWeight
+	}
+	if input.Language == "" {
+		input.Language = domain.DefaultLanguage
+	}
+	if !input.Language.Valid() {
+		return nil, fmt.Errorf("invalid language: %s", input.Language)
+	}
+	input.PromptSet = strings.TrimSpace(input.PromptSet)
+	if _, err := uc.prompts.Get(input.Language, input.PromptSet); err != nil {
+		return nil, fmt.Errorf("invalid prompt_set: %w (available: %s)", err, strings.Join(uc.prompts.Names(), ", "))
+	}
+	if err := input.RoundConfig.Validate(); err != nil {
+		return nil, err
+	}
+	if input.RoundConfig.DebateTurns == 0 {
+		input.RoundConfig.DebateTurns = domain.DefaultDebateTurns
+	}
+	if err := input.SpeakingOrder.Validate(); err != nil {
+		return nil, err
+	}
+	if input.QuestionFilter != nil {
+		if err := input.QuestionFilter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if input.Seed == 0 {
+		input.Seed = rand.Int64()
+	}
+	switch input.FinaleDecider {
+	case "":
+		input.FinaleDecider = domain.FinaleDeciderJudges
+	case domain.FinaleDeciderJudges, domain.FinaleDeciderJury, domain.FinaleDeciderAudience:
+	default:
+		return nil, fmt.Errorf("invalid finale_decider: %s", input.FinaleDecider)
+	}
 
 	game := &domain.Game{
-		ID:         uuid.NewString(),
-		MaxStrikes: input.MaxStrikes,
-		Status:     domain.GameStatusWaiting,
+		ID:              uuid.NewString(),
+		MaxStrikes:      input.MaxStrikes,
+		FinaleDecider:   input.FinaleDecider,
+		HumanTimeout:    input.HumanTimeout,
+		AudienceWindow:  input.AudienceWindow,
+		AudienceWeight:  audienceWeight,
+		Language:        input.Language,
+		PromptSet:       input.PromptSet,
+		Theme:           strings.TrimSpace(input.Theme),
+		ProvocativeHost: input.ProvocativeHost,
+		SpeakingOrder:   input.SpeakingOrder,
+		Seed:            input.Seed,
+		BlindVoting:     input.BlindVoting,
+		RoundConfig:     input.RoundConfig,
+		QuestionFilter:  input.QuestionFilter,
+		Status:          domain.GameStatusWaiting,
 	}
 
-	agents := make([]*domain.Agent, 0, input.NumAgents)
+	agents := make([]*domain.Agent, 0, input.NumAgents+len(input.HumanPlayers))
 	for i := 0; i < input.NumAgents; i++ {
 		a := &domain.Agent{
-			ID:   fmt.Sprintf("agent-%d", i+1),
-			Name: fmt.Sprintf("Agent %d", i+1),
+			ID:      fmt.Sprintf("agent-%d", i+1),
+			Name:    fmt.Sprintf("Agent %d", i+1),
+			Persona: domain.DefaultPersona(input.Language, i),
+			Model:   uc.defaultModel,
+		}
+		if i < len(input.Models) && strings.TrimSpace(input.Models[i]) != "" {
+			a.Model = strings.TrimSpace(input.Models[i])
+		}
+		if i < len(input.Personas) {
+			p := input.Personas[i]
+			a.Name = p.Name
+			a.Persona = &p
 		}
 		agents = append(agents, a)
 	}
+
+	// Os humanos recebem IDs iguais aos dos agentes para os LLMs os tratarem como mais um
+	for _, name := range input.HumanPlayers {
+		n := len(agents) + 1
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = fmt.Sprintf("Human %d", n)
+		}
+		agents = append(agents, &domain.Agent{
+			ID:    fmt.Sprintf("agent-%d", n),
+			Name:  name,
+			Human: true,
+		})
+	}
 	game.Agents = agents
 
 	if err := uc.gameRepo.Create(game); err != nil {
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+	"github.com/rafawastaken/ai-hunger-games/internal/service"
+)
+
+func newTestCreateGame(t *testing.T) *CreateGameUseCase {
+	t.Helper()
+	prompts, err := service.LoadPromptLibrary("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCreateGameUseCase(repository.NewInMemoryGameRepository(), repository.NewInMemoryPersonaRepository(), prompts, "m")
+}
+
+func TestCreateGameAudienceWeight(t *testing.T) {
+	weight := func(w float64) *float64 { return &w }
+
+	tests := []struct {
+		name    string
+		weight  *float64
+		want    float64
+		wantErr bool
+	}{
+		{name: "por omissão", weight: nil, want: 1},
+		{name: "zero só mostra o público", weight: weight(0), want: 0},
+		{name: "explícito", weight: weight(2.5), want: 2.5},
+		{name: "negativo", weight: weight(-1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := newTestCreateGame(t).Execute(CreateGameInput{AudienceWindow: 10, AudienceWeight: tt.weight})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && out.Game.AudienceWeight != tt.want {
+				t.Errorf("AudienceWeight = %v, want %v", out.Game.AudienceWeight, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateGamePromptSet(t *testing.T) {
+	uc := newTestCreateGame(t)
+
+	if _, err := uc.Execute(CreateGameInput{Language: domain.LanguageEnglish}); err != nil {
+		t.Errorf("set da língua: %v", err)
+	}
+	if _, err := uc.Execute(CreateGameInput{PromptSet: "nao-existe"}); !errors.Is(err, service.ErrUnknownPromptSet) {
+		t.Errorf("variante desconhecida: err = %v, want ErrUnknownPromptSet", err)
+	}
+}
@@ -0,0 +1,572 @@
+package usecase
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+var ErrUnsupportedExportFormat = errors.New("unsupported format, use md, html or jsonl")
+
+// ExportFormat é o formato da transcrição de um jogo.
+type ExportFormat string
+
+const (
+	ExportFormatMarkdown ExportFormat = "md"
+	ExportFormatHTML     ExportFormat = "html"
+	ExportFormatJSONL    ExportFormat = "jsonl" // um evento por linha
+)
+
+//go:embed templates/export.md.tmpl templates/export.html.tmpl
+var exportTemplates embed.FS
+
+var (
+	markdownExport = template.Must(template.New("export.md.tmpl").Funcs(template.FuncMap{
+		"quote": markdownQuote,
+	}).ParseFS(exportTemplates, "templates/export.md.tmpl"))
+	htmlExport = htmltemplate.Must(htmltemplate.New("export.html.tmpl").ParseFS(exportTemplates, "templates/export.html.tmpl"))
+)
+
+// ExportGameUseCase gera a transcrição completa de um jogo, para partilhar
+// (Markdown, HTML) ou para construir datasets (JSONL).
+type ExportGameUseCase struct {
+	gameRepo repository.GameRepository
+}
+
+func NewExportGameUseCase(repo repository.GameRepository) *ExportGameUseCase {
+	return &ExportGameUseCase{gameRepo: repo}
+}
+
+func (uc *ExportGameUseCase) Execute(gameID string, format ExportFormat) ([]byte, error) {
+	game, err := uc.gameRepo.Get(gameID)
+	if err != nil {
+		return nil, err
+	}
+	view := newExportView(game)
+
+	var buf bytes.Buffer
+	switch format {
+	case ExportFormatMarkdown:
+		err = markdownExport.Execute(&buf, view)
+	case ExportFormatHTML:
+		err = htmlExport.Execute(&buf, view)
+	case ExportFormatJSONL:
+		enc := json.NewEncoder(&buf)
+		for _, e := range view.events() {
+			if err = enc.Encode(e); err != nil {
+				break
+			}
+		}
+	default:
+		return nil, ErrUnsupportedExportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportEvent é uma linha do export em JSONL. Os campos vazios de cada tipo
+// são omitidos.
+type ExportEvent struct {
+	Type   string `json:"type"` // game, agent, question, answer, debate, vote, audience, judge, strike, elimination, finale_statement, finale_verdict, standing
+	GameID string `json:"game_id"`
+	Round  int    `json:"round,omitempty"`
+
+	AgentID string `json:"agent_id,omitempty"`
+	Agent   string `json:"agent,omitempty"` // nome do agente
+	Model   string `json:"model,omitempty"`
+	Persona string `json:"persona,omitempty"` // descrição da persona, nos eventos agent
+	Human   bool   `json:"human,omitempty"`
+
+	Stage         string   `json:"stage,omitempty"` // fase da final
+	Turn          int      `json:"turn,omitempty"`  // turno do debate
+	Text          string   `json:"text,omitempty"`
+	TargetID      string   `json:"target_id,omitempty"`
+	Target        string   `json:"target,omitempty"`
+	Justification string   `json:"justification,omitempty"`
+	TiedAgents    []string `json:"tied_agents,omitempty"`
+	Votes         int      `json:"votes,omitempty"`
+	Strikes       int      `json:"strikes,omitempty"` // strikes do agente depois deste
+	Placement     int      `json:"placement,omitempty"`
+
+	EliminatedRound int `json:"eliminated_round,omitempty"`
+
+	Language   domain.Language   `json:"language,omitempty"`
+	MaxStrikes int               `json:"max_strikes,omitempty"`
+	Status     domain.GameStatus `json:"status,omitempty"`
+	EndReason  domain.EndReason  `json:"end_reason,omitempty"`
+	WinnerID   string            `json:"winner_id,omitempty"`
+}
+
+// exportText são os textos fixos da transcrição, na língua do jogo.
+type exportText struct {
+	Lang            domain.Language
+	Game            string
+	Status          string
+	Winner          string
+	NoWinner        string
+	MaxStrikes      string
+	Agents          string
+	Agent           string
+	Model           string
+	Human           string
+	Persona         string
+	Round           string
+	Question        string
+	HostAsked       string
+	Answers         string
+	Debate          string
+	Turn            string
+	Summary         string
+	Votes           string
+	Audience        string
+	Judge           string
+	TiedAgents      string
+	JudgeChose      string
+	Strike          string
+	StrikeUnknown   string
+	Eliminated      string
+	Finale          string
+	Verdict         string
+	Standings       string
+	Placement       string
+	Strikes         string
+	VotesRecv       string
+	EliminatedRound string
+	Statuses        map[domain.GameStatus]string
+	EndReasons      map[domain.EndReason]string
+	Stages          map[domain.FinaleStage]string
+	Deciders        map[domain.FinaleDecider]string
+}
+
+var exportTexts = map[domain.Language]exportText{
+	domain.LanguagePortuguese: {
+		Lang: domain.LanguagePortuguese, Game: "Jogo", Status: "Estado", Winner: "Vencedor", NoWinner: "sem vencedor",
+		MaxStrikes: "Strikes para ser eliminado", Agents: "Agentes", Agent: "Agente", Model: "Modelo", Human: "humano",
+		Persona: "Persona", Round: "Ronda", Question: "Pergunta", HostAsked: "gerada pelo apresentador",
+		Answers: "Respostas", Debate: "Debate", Turn: "Turno", Summary: "Resumo dos primeiros turnos",
+		Votes: "Votação", Audience: "Público", Judge: "Juiz", TiedAgents: "Empate entre", JudgeChose: "O juiz escolheu",
+		Strike: "Strike", StrikeUnknown: "não registado, empate entre", Eliminated: "Eliminado", Finale: "Final", Verdict: "Veredicto", Standings: "Classificação final",
+		Placement: "Lugar", Strikes: "Strikes", VotesRecv: "Votos recebidos", EliminatedRound: "Eliminado na ronda",
+		Statuses: map[domain.GameStatus]string{
+			domain.GameStatusWaiting: "à espera", domain.GameStatusRunning: "a decorrer", domain.GameStatusFinished: "terminado",
+		},
+		EndReasons: map[domain.EndReason]string{
+			domain.EndReasonFinale:                  "decidido na final",
+			domain.EndReasonLastStanding:            "o último em jogo",
+			domain.EndReasonSimultaneousElimination: "eliminação simultânea",
+		},
+		Stages: map[domain.FinaleStage]string{
+			domain.FinaleStageOpening:          "Abertura",
+			domain.FinaleStageCrossExamination: "Contra-interrogatório",
+			domain.FinaleStageClosing:          "Alegações finais",
+		},
+		Deciders: map[domain.FinaleDecider]string{
+			domain.FinaleDeciderJudges: "painel de juízes", domain.FinaleDeciderJury: "júri dos eliminados", domain.FinaleDeciderAudience: "público",
+		},
+	},
+	domain.LanguageEnglish: {
+		Lang: domain.LanguageEnglish, Game: "Game", Status: "Status", Winner: "Winner", NoWinner: "no winner",
+		MaxStrikes: "Strikes to be eliminated", Agents: "Agents", Agent: "Agent", Model: "Model", Human: "human",
+		Persona: "Persona", Round: "Round", Question: "Question", HostAsked: "asked by the host",
+		Answers: "Answers", Debate: "Debate", Turn: "Turn", Summary: "Summary of the first turns",
+		Votes: "Votes", Audience: "Audience", Judge: "Judge", TiedAgents: "Tie between", JudgeChose: "The judge chose",
+		Strike: "Strike", StrikeUnknown: "not recorded, tie between", Eliminated: "Eliminated", Finale: "Finale", Verdict: "Verdict", Standings: "Final standings",
+		Placement: "Place", Strikes: "Strikes", VotesRecv: "Votes received", EliminatedRound: "Eliminated in round",
+		Statuses: map[domain.GameStatus]string{
+			domain.GameStatusWaiting: "waiting", domain.GameStatusRunning: "running", domain.GameStatusFinished: "finished",
+		},
+		EndReasons: map[domain.EndReason]string{
+			domain.EndReasonFinale:                  "decided in the finale",
+			domain.EndReasonLastStanding:            "last one standing",
+			domain.EndReasonSimultaneousElimination: "simultaneous elimination",
+		},
+		Stages: map[domain.FinaleStage]string{
+			domain.FinaleStageOpening:          "Opening",
+			domain.FinaleStageCrossExamination: "Cross-examination",
+			domain.FinaleStageClosing:          "Closing",
+		},
+		Deciders: map[domain.FinaleDecider]string{
+			domain.FinaleDeciderJudges: "judges panel", domain.FinaleDeciderJury: "jury of the eliminated", domain.FinaleDeciderAudience: "audience",
+		},
+	},
+}
+
+// exportColors é o número de cores de agentes do HTML (classes a0..a7).
+const exportColors = 8
+
+// exportRef identifica quem fala ou é votado: um agente ou um juiz.
+type exportRef struct {
+	ID    string
+	Name  string
+	Model string
+	Class string // classe CSS com a cor do agente no HTML
+}
+
+type exportLine struct {
+	exportRef
+	Text string
+}
+
+type exportVote struct {
+	Voter         exportRef
+	Target        exportRef
+	Justification string
+}
+
+type exportTally struct {
+	Agent exportRef
+	Votes int
+}
+
+type exportTurn struct {
+	Turn  int
+	Lines []exportLine
+}
+
+type exportStage struct {
+	Stage domain.FinaleStage
+	Title string
+	Lines []exportLine
+}
+
+type exportJudge struct {
+	Tied          []exportRef
+	Target        exportRef
+	Justification string
+}
+
+type exportStrike struct {
+	Agent   exportRef
+	Strikes int         // strikes do agente depois deste
+	Unknown []exportRef // jogos antigos: o empate que não se sabe como foi desfeito
+}
+
+type exportFinale struct {
+	Decider  string
+	Stages   []exportStage
+	Verdicts []exportVote
+	Audience []exportTally
+	Winner   *exportRef
+}
+
+type exportRound struct {
+	Index         int
+	Question      string
+	AutoQuestion  bool
+	Answers       []exportLine
+	DebateSummary string
+	Debate        []exportTurn
+	Votes         []exportVote
+	Audience      []exportTally
+	Judge         *exportJudge
+	Strike        *exportStrike
+	Eliminated    []exportRef
+	Finale        *exportFinale
+}
+
+type exportAgent struct {
+	exportRef
+	Persona         string
+	Human           bool
+	Strikes         int
+	Placement       int
+	EliminatedRound int
+	VotesReceived   int
+}
+
+type exportView struct {
+	T         exportText
+	Game      *domain.Game
+	Status    string
+	EndReason string
+	Winner    *exportRef
+	Agents    []exportAgent
+	Rounds    []exportRound
+	Standings []exportAgent
+}
+
+func newExportView(game *domain.Game) *exportView {
+	t, ok := exportTexts[game.Lang()]
+	if !ok {
+		t = exportTexts[domain.DefaultLanguage]
+	}
+	v := &exportView{
+		T:         t,
+		Game:      game,
+		Status:    t.Statuses[game.Status],
+		EndReason: t.EndReasons[game.EndReason],
+	}
+	if game.WinnerID != "" {
+		w := v.ref(game.WinnerID)
+		v.Winner = &w
+	}
+
+	placements := make(map[string]domain.Standing, len(game.Standings))
+	for _, s := range game.Standings {
+		placements[s.AgentID] = s
+	}
+	votes := game.VotesReceived()
+	for _, a := range game.Agents {
+		ea := exportAgent{
+			exportRef:       v.ref(a.ID),
+			Human:           a.Human,
+			Strikes:         a.Strikes,
+			Placement:       placements[a.ID].Placement,
+			EliminatedRound: a.EliminatedRound,
+			VotesReceived:   votes[a.ID],
+		}
+		if a.Persona != nil {
+			ea.Persona = a.Persona.Description
+		}
+		v.Agents = append(v.Agents, ea)
+	}
+	for _, s := range game.Standings {
+		for _, a := range v.Agents {
+			if a.ID == s.AgentID {
+				v.Standings = append(v.Standings, a)
+			}
+		}
+	}
+
+	strikes := make(map[string]int)
+	for _, r := range game.Rounds {
+		v.Rounds = append(v.Rounds, v.round(r, strikes))
+	}
+	return v
+}
+
+// legacyStrike refaz o strike de uma ronda gravada antes de haver StrikeID:
+// os mais votados entre os agentes em jogo nessa ronda. Com um só é esse; num
+// empate não se sabe (o stream pedia ao juiz e o POST dava strike a todos).
+func legacyStrike(game *domain.Game, r *domain.Round) []string {
+	if r.Finale != nil {
+		return nil
+	}
+	var active []*domain.Agent
+	for _, a := range game.Agents {
+		if !a.Eliminated || a.EliminatedRound >= r.Index {
+			active = append(active, a)
+		}
+	}
+	return strikeCandidates(r, active)
+}
+
+// ref resolve o ID de um agente ou juiz ("judge", "judge-N") para o nome.
+func (v *exportView) ref(id string) exportRef {
+	for i, a := range v.Game.Agents {
+		if a.ID == id {
+			return exportRef{ID: id, Name: a.Name, Model: a.Model, Class: fmt.Sprintf("a%d", i%exportColors)}
+		}
+	}
+	if id == "judge" {
+		return exportRef{ID: id, Name: v.T.Judge, Class: "judge"}
+	}
+	if n, ok := strings.CutPrefix(id, "judge-"); ok {
+		return exportRef{ID: id, Name: v.T.Judge + " " + n, Class: "judge"}
+	}
+	return exportRef{ID: id, Name: id}
+}
+
+func (v *exportView) vote(voterID, targetID, justification string) exportVote {
+	return exportVote{Voter: v.ref(voterID), Target: v.ref(targetID), Justification: justification}
+}
+
+func (v *exportView) tally(t map[string]int) []exportTally {
+	res := make([]exportTally, 0, len(t))
+	for id, n := range t {
+		res = append(res, exportTally{Agent: v.ref(id), Votes: n})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Votes != res[j].Votes {
+			return res[i].Votes > res[j].Votes
+		}
+		return res[i].Agent.ID < res[j].Agent.ID
+	})
+	return res
+}
+
+// round monta a ronda. strikes acumula os strikes de cada agente ronda a
+// ronda, para mostrar quantos tinha depois de cada um.
+func (v *exportView) round(r *domain.Round, strikes map[string]int) exportRound {
+	er := exportRound{
+		Index:         r.Index,
+		Question:      r.Question,
+		AutoQuestion:  r.AutoQuestion,
+		DebateSummary: r.DebateSummary,
+	}
+	for _, a := range r.Answers {
+		er.Answers = append(er.Answers, exportLine{exportRef: v.ref(a.AgentID), Text: a.Text})
+	}
+	for _, m := range r.Debate {
+		if n := len(er.Debate); n == 0 || er.Debate[n-1].Turn != m.Turn {
+			er.Debate = append(er.Debate, exportTurn{Turn: m.Turn})
+		}
+		turn := &er.Debate[len(er.Debate)-1]
+		turn.Lines = append(turn.Lines, exportLine{exportRef: v.ref(m.AgentID), Text: m.Text})
+	}
+	for _, vote := range r.Votes {
+		er.Votes = append(er.Votes, v.vote(vote.VoterID, vote.TargetID, vote.Justification))
+	}
+	if r.Audience != nil {
+		er.Audience = v.tally(r.Audience.Tally)
+	}
+	if j := r.Judge; j != nil {
+		ej := &exportJudge{Target: v.ref(j.TargetID), Justification: j.Justification}
+		for _, id := range j.TiedAgents {
+			ej.Tied = append(ej.Tied, v.ref(id))
+		}
+		er.Judge = ej
+	}
+	strikeID := r.StrikeID
+	if strikeID == "" {
+		tied := legacyStrike(v.Game, r)
+		switch {
+		case len(tied) == 1:
+			strikeID = tied[0]
+		case len(tied) > 1:
+			es := &exportStrike{}
+			for _, id := range tied {
+				es.Unknown = append(es.Unknown, v.ref(id))
+			}
+			er.Strike = es
+		}
+	}
+	if strikeID != "" {
+		strikes[strikeID]++
+		er.Strike = &exportStrike{Agent: v.ref(strikeID), Strikes: strikes[strikeID]}
+	}
+	for _, id := range r.Eliminated {
+		er.Eliminated = append(er.Eliminated, v.ref(id))
+	}
+
+	if f := r.Finale; f != nil {
+		ef := &exportFinale{Decider: v.T.Deciders[f.Decider]}
+		for _, st := range f.Statements {
+			if n := len(ef.Stages); n == 0 || ef.Stages[n-1].Stage != st.Stage {
+				ef.Stages = append(ef.Stages, exportStage{Stage: st.Stage, Title: v.T.Stages[st.Stage]})
+			}
+			stage := &ef.Stages[len(ef.Stages)-1]
+			stage.Lines = append(stage.Lines, exportLine{exportRef: v.ref(st.AgentID), Text: st.Text})
+		}
+		for _, vd := range f.Verdicts {
+			ef.Verdicts = append(ef.Verdicts, v.vote(vd.VoterID, vd.TargetID, vd.Justification))
+		}
+		if f.Audience != nil {
+			ef.Audience = v.tally(f.Audience.Tally)
+		}
+		if f.WinnerID != "" {
+			w := v.ref(f.WinnerID)
+			ef.Winner = &w
+		}
+		er.Finale = ef
+	}
+	return er
+}
+
+// events devolve a transcrição como uma sequência de eventos, pela ordem do jogo.
+func (v *exportView) events() []ExportEvent {
+	g := v.Game
+	events := []ExportEvent{{
+		Type: "game", GameID: g.ID, Language: g.Lang(), MaxStrikes: g.MaxStrikes,
+		Status: g.Status, EndReason: g.EndReason, WinnerID: g.WinnerID,
+	}}
+	line := func(typ string, round int, l exportLine) ExportEvent {
+		return ExportEvent{Type: typ, GameID: g.ID, Round: round, AgentID: l.ID, Agent: l.Name, Model: l.Model, Text: l.Text}
+	}
+	vote := func(typ string, round int, vote exportVote) ExportEvent {
+		return ExportEvent{
+			Type: typ, GameID: g.ID, Round: round, AgentID: vote.Voter.ID, Agent: vote.Voter.Name, Model: vote.Voter.Model,
+			TargetID: vote.Target.ID, Target: vote.Target.Name, Justification: vote.Justification,
+		}
+	}
+	tally := func(round int, t []exportTally) {
+		for _, e := range t {
+			events = append(events, ExportEvent{Type: "audience", GameID: g.ID, Round: round, TargetID: e.Agent.ID, Target: e.Agent.Name, Votes: e.Votes})
+		}
+	}
+
+	for _, a := range v.Agents {
+		events = append(events, ExportEvent{
+			Type: "agent", GameID: g.ID, AgentID: a.ID, Agent: a.Name, Model: a.Model, Persona: a.Persona, Human: a.Human,
+		})
+	}
+
+	for _, r := range v.Rounds {
+		events = append(events, ExportEvent{Type: "question", GameID: g.ID, Round: r.Index, Text: r.Question})
+		for _, a := range r.Answers {
+			events = append(events, line("answer", r.Index, a))
+		}
+		for _, turn := range r.Debate {
+			for _, m := range turn.Lines {
+				e := line("debate", r.Index, m)
+				e.Turn = turn.Turn
+				events = append(events, e)
+			}
+		}
+		for _, vt := range r.Votes {
+			events = append(events, vote("vote", r.Index, vt))
+		}
+		tally(r.Index, r.Audience)
+		if j := r.Judge; j != nil {
+			e := ExportEvent{Type: "judge", GameID: g.ID, Round: r.Index, TargetID: j.Target.ID, Target: j.Target.Name, Justification: j.Justification}
+			for _, t := range j.Tied {
+				e.TiedAgents = append(e.TiedAgents, t.ID)
+			}
+			events = append(events, e)
+		}
+		if s := r.Strike; s != nil && len(s.Unknown) > 0 {
+			e := ExportEvent{Type: "strike", GameID: g.ID, Round: r.Index}
+			for _, t := range s.Unknown {
+				e.TiedAgents = append(e.TiedAgents, t.ID)
+			}
+			events = append(events, e)
+		} else if s != nil {
+			events = append(events, ExportEvent{Type: "strike", GameID: g.ID, Round: r.Index, AgentID: s.Agent.ID, Agent: s.Agent.Name, Strikes: s.Strikes})
+		}
+		if f := r.Finale; f != nil {
+			for _, st := range f.Stages {
+				for _, l := range st.Lines {
+					e := line("finale_statement", r.Index, l)
+					e.Stage = string(st.Stage)
+					events = append(events, e)
+				}
+			}
+			for _, vd := range f.Verdicts {
+				events = append(events, vote("finale_verdict", r.Index, vd))
+			}
+			tally(r.Index, f.Audience)
+		}
+		for _, a := range r.Eliminated {
+			events = append(events, ExportEvent{Type: "elimination", GameID: g.ID, Round: r.Index, AgentID: a.ID, Agent: a.Name})
+		}
+	}
+
+	for _, s := range v.Standings {
+		events = append(events, ExportEvent{
+			Type: "standing", GameID: g.ID, AgentID: s.ID, Agent: s.Name, Model: s.Model,
+			Placement: s.Placement, Strikes: s.Strikes, Votes: s.VotesReceived, EliminatedRound: s.EliminatedRound,
+		})
+	}
+	return events
+}
+
+// markdownQuote põe o texto num bloco de citação, linha a linha, para o
+// Markdown que os agentes escrevem não partir a estrutura da transcrição.
+func markdownQuote(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, l := range lines {
+		lines[i] = "> " + l
+	}
+	return strings.Join(lines, "\n")
+}
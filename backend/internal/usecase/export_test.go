@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+// Um jogo gravado antes de haver strike_id: o strike é refeito pelos votos e,
+// num empate, fica como não registado.
+func TestExportLegacyStrikes(t *testing.T) {
+	game := &domain.Game{ID: "old", MaxStrikes: 2, Status: domain.GameStatusFinished, Agents: []*domain.Agent{
+		{ID: "agent-1", Name: "Ana", Strikes: 1},
+		{ID: "agent-2", Name: "Bia", Strikes: 2, Eliminated: true, EliminatedRound: 2},
+		{ID: "agent-3", Name: "Caio", Strikes: 1},
+	}}
+	game.Rounds = []*domain.Round{
+		{Index: 1, Question: "Q1?", Votes: []domain.Vote{
+			{VoterID: "agent-1", TargetID: "agent-2"},
+			{VoterID: "agent-2", TargetID: "agent-3"},
+			{VoterID: "agent-3", TargetID: "agent-2"},
+		}},
+		{Index: 2, Question: "Q2?", Eliminated: []string{"agent-2"}, Votes: []domain.Vote{
+			{VoterID: "agent-1", TargetID: "agent-2"},
+			{VoterID: "agent-2", TargetID: "agent-3"},
+			{VoterID: "agent-3", TargetID: "agent-1"},
+		}},
+	}
+	repo := repository.NewInMemoryGameRepository()
+	if err := repo.Create(game); err != nil {
+		t.Fatal(err)
+	}
+	uc := NewExportGameUseCase(repo)
+
+	md, err := uc.Execute("old", ExportFormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"⚠️ **Strike:** Bia (1/2)",
+		"⚠️ **Strike:** não registado, empate entre Ana, Bia, Caio",
+	} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("markdown sem %q:\n%s", want, md)
+		}
+	}
+
+	html, err := uc.Execute("old", ExportFormatHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `não registado, empate entre <span class="a0">Ana</span>, <span class="a1">Bia</span>`; !strings.Contains(string(html), want) {
+		t.Errorf("html sem %q", want)
+	}
+
+	data, err := uc.Execute("old", ExportFormatJSONL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var strikes []ExportEvent
+	for _, line := range bytes.Split(bytes.TrimSpace(data), []byte("\n")) {
+		var e ExportEvent
+		if err := json.Unmarshal(line, &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.Type == "strike" {
+			strikes = append(strikes, e)
+		}
+	}
+	if len(strikes) != 2 {
+		t.Fatalf("%d eventos strike, want 2", len(strikes))
+	}
+	if s := strikes[0]; s.AgentID != "agent-2" || s.Strikes != 1 {
+		t.Errorf("strike da ronda 1 = %+v, want agent-2 com 1", s)
+	}
+	if s := strikes[1]; s.AgentID != "" || len(s.TiedAgents) != 3 {
+		t.Errorf("strike da ronda 2 = %+v, want sem agente e com 3 empatados", s)
+	}
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// finaleJudges é o tamanho do painel de juízes da final.
+const finaleJudges = 3
+
+// finaleAudienceWindow é a janela usada quando a final é decidida pelo público
+// mas o jogo não tem votação do público configurada.
+const finaleAudienceWindow = 30
+
+// playFinale corre a final quando só restam 2 agentes: abertura, interrogatório
+// cruzado e alegações finais de cada finalista, seguidas do veredicto.
+// Numa ronda normal os dois votariam sempre um no outro e empatavam.
+func (uc *PlayRoundUseCase) playFinale(ctx context.Context, game *domain.Game, round *domain.Round, finalists []*domain.Agent, emit RoundEventFunc) error {
+	steps := newRoundSteps(ctx, round, func() error {
+		return uc.gameRepo.Update(game)
+	})
+
+	if round.Finale == nil {
+		finale := &domain.Finale{
+			Decider: game.FinaleDecider,
+		}
+		for _, a := range finalists {
+			finale.Finalists = append(finale.Finalists, a.ID)
+		}
+
+		if finale.Decider == domain.FinaleDeciderJury && len(game.EliminatedAgents()) == 0 {
+			// Sem eliminados não há júri possível
+			finale.Decider = domain.FinaleDeciderJudges
+		}
+		if finale.Decider != domain.FinaleDeciderJury && finale.Decider != domain.FinaleDeciderAudience {
+			finale.Decider = domain.FinaleDeciderJudges
+		}
+		round.Finale = finale
+	}
+	finale := round.Finale
+
+	// 1) Discursos (a mesma ordem em todas as fases da final). O anúncio de
+	// cada fase é um passo à parte, para não ser repetido quando o discurso
+	// seguinte falha e a ronda é repetida.
+	finalists = speakingOrder(game, round, domain.PhaseFinale, finalists)
+	for _, stage := range domain.FinaleStages {
+		err := steps.do("finale_"+string(stage), func() error {
+			emit("phase", map[string]string{"phase": "finale_" + string(stage)})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, agent := range finalists {
+			err := steps.do("finale_"+string(stage), func() error {
+				text, err := uc.finaleStatement(ctx, game, round, agent, stage, emit)
+				if err != nil {
+					return err
+				}
+				st := domain.FinaleStatement{
+					AgentID: agent.ID,
+					Stage:   stage,
+					Text:    text,
+				}
+				finale.Statements = append(finale.Statements, st)
+				emit("finale_statement", st)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// 2) Veredicto
+	err := steps.do("finale_verdict", func() error {
+		emit("phase", map[string]string{"phase": "finale_verdict"})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	switch finale.Decider {
+	case domain.FinaleDeciderJury:
+		for _, juror := range game.EliminatedAgents() {
+			err := steps.do("finale_verdict", func() error {
+				return uc.finaleJuryVerdict(ctx, game, round, juror, emit)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	case domain.FinaleDeciderAudience:
+		err := steps.do("finale_verdict", func() error {
+			return uc.finaleAudienceVotes(ctx, game, round, emit)
+		})
+		if err != nil {
+			return err
+		}
+	default:
+		for j := 0; j < finaleJudges; j++ {
+			err := steps.do("finale_verdict", func() error {
+				return uc.finaleJudgeVerdict(ctx, game, round, false, emit)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// 3) Vencedor: enquanto houver empate é chamado mais um juiz. Cada
+	// desempate só conta a partir da sua volta, para uma ronda retomada
+	// refazer as mesmas voltas (e os mesmos passos).
+	winnerID := finaleLeader(finale, 0)
+	for tiebreaks := 1; winnerID == ""; tiebreaks++ {
+		err := steps.do("finale_tiebreak", func() error {
+			emit("phase", map[string]string{"phase": "finale_tiebreak"})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = steps.do("finale_tiebreak", func() error {
+			return uc.finaleJudgeVerdict(ctx, game, round, true, emit)
+		})
+		if err != nil {
+			return err
+		}
+		winnerID = finaleLeader(finale, tiebreaks)
+	}
+
+	return steps.do("finale_winner", func() error {
+		finale.WinnerID = winnerID
+		game.WinnerID = winnerID
+
+		for _, agent := range finalists {
+			if agent.ID != winnerID {
+				game.Eliminate(agent, round)
+			}
+		}
+		return nil
+	})
+}
+
+// finaleJudgeVerdict pede o veredicto a mais um juiz. Os juízes de desempate
+// continuam a numeração dos anteriores.
+func (uc *PlayRoundUseCase) finaleJudgeVerdict(ctx context.Context, game *domain.Game, round *domain.Round, tiebreak bool, emit RoundEventFunc) error {
+	j := 0
+	for _, v := range round.Finale.Verdicts {
+		if game.Agent(v.VoterID) == nil {
+			j++
+		}
+	}
+
+	targetID, justification, err := uc.groq.GenerateFinaleJudgeVerdict(ctx, game, round, j)
+	if err != nil {
+		return err
+	}
+	judgeID := fmt.Sprintf("judge-%d", j+1)
+	justification, err = uc.moderate(ctx, game, round, judgeID, domain.PhaseFinale, "justification", justification, nil, emit)
+	if err != nil {
+		return err
+	}
+	v := domain.FinaleVerdict{
+		VoterID:       judgeID,
+		TargetID:      targetID,
+		Justification: justification,
+		Tiebreak:      tiebreak,
+	}
+	round.Finale.Verdicts = append(round.Finale.Verdicts, v)
+	emit("finale_verdict", v)
+	return nil
+}
+
+func (uc *PlayRoundUseCase) finaleJuryVerdict(ctx context.Context, game *domain.Game, round *domain.Round, juror *domain.Agent, emit RoundEventFunc) error {
+	targetID, justification, ok, err := uc.juryVote(ctx, game, round, juror, emit)
+	if err != nil || !ok {
+		return err
+	}
+	v := domain.FinaleVerdict{
+		VoterID:       juror.ID,
+		TargetID:      targetID,
+		Justification: justification,
+	}
+	round.Finale.Verdicts = append(round.Finale.Verdicts, v)
+	emit("finale_verdict", v)
+	return nil
+}
+
+func (uc *PlayRoundUseCase) finaleAudienceVotes(ctx context.Context, game *domain.Game, round *domain.Round, emit RoundEventFunc) error {
+	window := game.AudienceWindow
+	if window <= 0 {
+		window = finaleAudienceWindow
+	}
+
+	// Cópia só para a janela da final não alterar a configuração do jogo
+	g := *game
+	g.AudienceWindow = window
+
+	tally, err := uc.collectAudienceVotes(ctx, &g, round, round.Finale.Finalists, emit)
+	if err != nil {
+		return err
+	}
+	round.Finale.Audience = &domain.AudienceResult{
+		Tally:  tally,
+		Weight: 1,
+	}
+	return nil
+}
+
+// finaleLeader conta os veredictos (e os votos do público, se for ele a
+// decidir), com só os primeiros tiebreaks juízes de desempate, e devolve o
+// finalista à frente ("" se estiverem empatados).
+func finaleLeader(finale *domain.Finale, tiebreaks int) string {
+	counts := make(map[string]int)
+	for _, v := range finale.Verdicts {
+		if v.Tiebreak {
+			if tiebreaks == 0 {
+				continue
+			}
+			tiebreaks--
+		}
+		counts[v.TargetID]++
+	}
+	if finale.Audience != nil {
+		for id, n := range finale.Audience.Tally {
+			counts[id] += n
+		}
+	}
+
+	a, b := finale.Finalists[0], finale.Finalists[1]
+	switch {
+	case counts[a] > counts[b]:
+		return a
+	case counts[b] > counts[a]:
+		return b
+	}
+	return ""
+}
+
+func (uc *PlayRoundUseCase) finaleStatement(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, stage domain.FinaleStage, emit RoundEventFunc) (string, error) {
+	var text string
+	var ok bool
+	var err error
+	if !agent.Human {
+		generate := func(ctx context.Context) (string, bool, error) {
+			return aiTurn(ctx, round, agent, domain.PhaseFinale, emit, func(ctx context.Context) (string, error) {
+				return uc.groq.GenerateFinaleStatement(ctx, game, round, agent, stage)
+			})
+		}
+		text, ok, err = generate(ctx)
+		if ok {
+			text, err = uc.moderate(ctx, game, round, agent.ID, domain.PhaseFinale, "text", text, generate, emit)
+		}
+	} else {
+		var in HumanInput
+		in, ok, err = uc.awaitHuman(ctx, game, round, agent, domain.PhaseFinale, validateHumanText, emit)
+		text = in.Text
+	}
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return noAnswerText(game.Lang()), nil
+	}
+	return limitWords(text, round.Config.MaxFinaleWords), nil
+}
+
+func (uc *PlayRoundUseCase) juryVote(ctx context.Context, game *domain.Game, round *domain.Round, juror *domain.Agent, emit RoundEventFunc) (string, string, bool, error) {
+	if !juror.Human {
+		v, ok, err := aiTurn(ctx, round, juror, domain.PhaseVote, emit, func(ctx context.Context) (domain.FinaleVerdict, error) {
+			targetID, justification, err := uc.groq.GenerateJuryVote(ctx, game, round, juror)
+			return domain.FinaleVerdict{TargetID: targetID, Justification: justification}, err
+		})
+		if ok {
+			v.Justification, err = uc.moderate(ctx, game, round, juror.ID, domain.PhaseFinale, "justification", v.Justification, nil, emit)
+			ok = err == nil
+		}
+		return v.TargetID, v.Justification, ok, err
+	}
+
+	validate := validateHumanVote(round.Finale.Finalists)
+	in, ok, err := uc.awaitHuman(ctx, game, round, juror, domain.PhaseVote, validate, emit)
+	return in.TargetID, strings.TrimSpace(in.Justification), ok, err
+}
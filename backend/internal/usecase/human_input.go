@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+var (
+	ErrNoPendingInput = errors.New("no pending input for this seat")
+	ErrInvalidInput   = errors.New("invalid input")
+)
+
+// maxHumanTextLen limita o tamanho das intervenções humanas.
+const maxHumanTextLen = 1000
+
+// HumanInput é o que um jogador humano submete quando o motor da ronda espera por ele.
+type HumanInput struct {
+	AgentID       string       `json:"agent_id"`
+	Phase         domain.Phase `json:"phase"`
+	Text          string       `json:"text"`
+	TargetID      string       `json:"target_id,omitempty"`
+	Justification string       `json:"justification,omitempty"`
+}
+
+type pendingInput struct {
+	ch       chan HumanInput
+	validate func(HumanInput) error
+}
+
+// HumanInputHub liga o motor da ronda (que espera) aos pedidos HTTP (que submetem).
+type HumanInputHub struct {
+	mu      sync.Mutex
+	pending map[string]*pendingInput
+}
+
+func NewHumanInputHub() *HumanInputHub {
+	return &HumanInputHub{
+		pending: make(map[string]*pendingInput),
+	}
+}
+
+func inputKey(gameID string, round int, agentID string, phase domain.Phase) string {
+	return fmt.Sprintf("%s/%d/%s/%s", gameID, round, agentID, phase)
+}
+
+// Await bloqueia até o humano submeter, o timeout expirar (ok=false) ou o contexto ser cancelado.
+func (h *HumanInputHub) Await(ctx context.Context, gameID string, round int, agentID string, phase domain.Phase, timeout time.Duration, validate func(HumanInput) error) (HumanInput, bool, error) {
+	key := inputKey(gameID, round, agentID, phase)
+	p := &pendingInput{
+		ch:       make(chan HumanInput, 1),
+		validate: validate,
+	}
+
+	h.mu.Lock()
+	h.pending[key] = p
+	h.mu.Unlock()
+
+	defer h.remove(key, p)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case in := <-p.ch:
+		return in, true, nil
+	case <-timer.C:
+		// Um Submit que já tirou o pedido foi aceite (202): a intervenção
+		// está no canal e conta, mesmo tendo chegado no limite
+		if !h.remove(key, p) {
+			return <-p.ch, true, nil
+		}
+		return HumanInput{}, false, nil
+	case <-ctx.Done():
+		return HumanInput{}, false, ctx.Err()
+	}
+}
+
+// remove tira o pedido pendente, se ainda for p. Devolve false se já tiver
+// sido tirado por um Submit.
+func (h *HumanInputHub) remove(key string, p *pendingInput) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.pending[key] != p {
+		return false
+	}
+	delete(h.pending, key)
+	return true
+}
+
+// Submit entrega a intervenção ao motor da ronda, se este estiver à espera dela.
+func (h *HumanInputHub) Submit(gameID string, round int, in HumanInput) error {
+	key := inputKey(gameID, round, in.AgentID, in.Phase)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p, ok := h.pending[key]
+	if !ok {
+		return ErrNoPendingInput
+	}
+	if p.validate != nil {
+		if err := p.validate(in); err != nil {
+			return err
+		}
+	}
+	delete(h.pending, key)
+	p.ch <- in
+	return nil
+}
+
+// === Validação ===
+
+func validateHumanText(in HumanInput) error {
+	text := strings.TrimSpace(in.Text)
+	if text == "" {
+		return fmt.Errorf("%w: text is required", ErrInvalidInput)
+	}
+	if len([]rune(text)) > maxHumanTextLen {
+		return fmt.Errorf("%w: text longer than %d characters", ErrInvalidInput, maxHumanTextLen)
+	}
+	return nil
+}
+
+// validateHumanVote garante que o voto é num dos alvos permitidos.
+func validateHumanVote(targets []string) func(HumanInput) error {
+	return func(in HumanInput) error {
+		for _, t := range targets {
+			if in.TargetID == t {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: target_id must be one of %s", ErrInvalidInput, strings.Join(targets, ", "))
+	}
+}
+
+// === Integração com o motor da ronda ===
+
+// SubmitHumanInput é chamado pelo endpoint de submissões.
+func (uc *PlayRoundUseCase) SubmitHumanInput(gameID string, round int, in HumanInput) error {
+	return uc.humans.Submit(gameID, round, in)
+}
+
+func (uc *PlayRoundUseCase) awaitHuman(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, phase domain.Phase, validate func(HumanInput) error, emit RoundEventFunc) (HumanInput, bool, error) {
+	// O tempo da fase, se a ronda o definir, substitui o tempo geral dos humanos
+	timeout := round.Config.Timeout(phase)
+	if timeout <= 0 {
+		timeout = time.Duration(game.HumanTimeout) * time.Second
+	}
+
+	emit("awaiting_input", map[string]interface{}{
+		"agent_id":        agent.ID,
+		"phase":           phase,
+		"round":           round.Index,
+		"timeout_seconds": int(timeout / time.Second),
+	})
+
+	in, ok, err := uc.humans.Await(ctx, game.ID, round.Index, agent.ID, phase, timeout, validate)
+	if err != nil {
+		return HumanInput{}, false, err
+	}
+	if !ok {
+		emit("input_timeout", map[string]interface{}{
+			"agent_id": agent.ID,
+			"phase":    phase,
+		})
+	}
+	in.Text = strings.TrimSpace(in.Text)
+	return in, ok, nil
+}
+
+// humanVoteTargets devolve os agentes ativos em quem o humano pode votar.
+func humanVoteTargets(activeAgents []*domain.Agent, voter *domain.Agent) []string {
+	var res []string
+	for _, a := range activeAgents {
+		if a.ID != voter.ID {
+			res = append(res, a.ID)
+		}
+	}
+	return res
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// waitPending espera até o Await registar o pedido.
+func waitPending(t *testing.T, h *HumanInputHub) {
+	t.Helper()
+	for i := 0; i < 1000; i++ {
+		h.mu.Lock()
+		n := len(h.pending)
+		h.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("Await não registou o pedido")
+}
+
+func TestHumanInputHubSubmit(t *testing.T) {
+	h := NewHumanInputHub()
+	in := HumanInput{AgentID: "agent-2", Phase: domain.PhaseAnswer, Text: "olá"}
+
+	if err := h.Submit("g", 1, in); !errors.Is(err, ErrNoPendingInput) {
+		t.Fatalf("Submit sem pedido: err = %v, want ErrNoPendingInput", err)
+	}
+
+	type result struct {
+		in  HumanInput
+		ok  bool
+		err error
+	}
+	done := make(chan result)
+	go func() {
+		got, ok, err := h.Await(context.Background(), "g", 1, "agent-2", domain.PhaseAnswer, time.Minute, validateHumanText)
+		done <- result{got, ok, err}
+	}()
+	waitPending(t, h)
+
+	if err := h.Submit("g", 1, HumanInput{AgentID: "agent-2", Phase: domain.PhaseAnswer}); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("Submit inválido: err = %v, want ErrInvalidInput", err)
+	}
+	if err := h.Submit("g", 1, in); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	res := <-done
+	if res.err != nil || !res.ok || res.in.Text != "olá" {
+		t.Errorf("Await = %+v", res)
+	}
+	if err := h.Submit("g", 1, in); !errors.Is(err, ErrNoPendingInput) {
+		t.Errorf("segundo Submit: err = %v, want ErrNoPendingInput", err)
+	}
+}
+
+func TestHumanInputHubTimeout(t *testing.T) {
+	h := NewHumanInputHub()
+	_, ok, err := h.Await(context.Background(), "g", 1, "agent-2", domain.PhaseDebate, time.Millisecond, nil)
+	if err != nil || ok {
+		t.Fatalf("Await = ok %v, err %v, want timeout", ok, err)
+	}
+	if err := h.Submit("g", 1, HumanInput{AgentID: "agent-2", Phase: domain.PhaseDebate, Text: "tarde"}); !errors.Is(err, ErrNoPendingInput) {
+		t.Errorf("Submit depois do timeout: err = %v, want ErrNoPendingInput", err)
+	}
+}
+
+// Um Submit aceite no instante em que o tempo acaba não se pode perder: o
+// Await só desiste se ainda conseguir tirar o pedido; se o Submit já o tirou,
+// a intervenção está no canal.
+func TestHumanInputHubSubmitAtDeadline(t *testing.T) {
+	h := NewHumanInputHub()
+	key := inputKey("g", 1, "agent-2", domain.PhaseAnswer)
+	p := &pendingInput{ch: make(chan HumanInput, 1)}
+	h.pending[key] = p
+
+	if err := h.Submit("g", 1, HumanInput{AgentID: "agent-2", Phase: domain.PhaseAnswer, Text: "x"}); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	// O timer disparou depois do Submit
+	if h.remove(key, p) {
+		t.Fatal("remove depois do Submit devia falhar")
+	}
+	select {
+	case in := <-p.ch:
+		if in.Text != "x" {
+			t.Errorf("in = %+v", in)
+		}
+	default:
+		t.Fatal("a intervenção aceite não está no canal")
+	}
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+	"github.com/rafawastaken/ai-hunger-games/internal/service"
+)
+
+// noAnswerTexts substituem a resposta de um humano que não respondeu a tempo.
+var noAnswerTexts = map[domain.Language]string{
+	domain.LanguagePortuguese: "(não respondeu a tempo)",
+	domain.LanguageEnglish:    "(did not answer in time)",
+}
+
+func noAnswerText(lang domain.Language) string {
+	if text, ok := noAnswerTexts[lang]; ok {
+		return text
+	}
+	return noAnswerTexts[domain.DefaultLanguage]
+}
+
+// moderatedTexts substituem um texto que a moderação removeu por inteiro.
+var moderatedTexts = map[domain.Language]string{
+	domain.LanguagePortuguese: "(mensagem removida pela moderação)",
+	domain.LanguageEnglish:    "(message removed by moderation)",
+}
+
+func moderatedText(lang domain.Language) string {
+	if text, ok := moderatedTexts[lang]; ok {
+		return text
+	}
+	return moderatedTexts[domain.DefaultLanguage]
+}
+
+// errorMessage liga um erro base à sua tradução.
+type errorMessage struct {
+	err error
+	msg string
+}
+
+// errorMessages traduz os erros que chegam aos jogadores. Os erros são
+// escritos em inglês, por isso o inglês não precisa de tradução. A lista é
+// percorrida por ordem e ganha o primeiro erro base que o erro contém, por
+// isso os erros que embrulham outros (ex: ErrQuestionGeneration) vêm antes.
+var errorMessages = map[domain.Language][]errorMessage{
+	domain.LanguagePortuguese: {
+		{repository.ErrGameNotFound, "jogo não encontrado"},
+		{ErrGameFinished, "o jogo já terminou"},
+		{ErrQuestionRequired, "a pergunta é obrigatória"},
+		{ErrNoActiveAgents, "o jogo não tem agentes ativos"},
+		{ErrInvalidRoundConfig, "configuração da ronda inválida"},
+		{ErrQuestionRejected, "pergunta recusada"},
+		{ErrQuestionGeneration, "não foi possível gerar uma pergunta"},
+		{ErrQuestionMismatch, "a pergunta não corresponde ao question_id"},
+		{ErrQuestionNotAllowed, "a pergunta do banco não é da língua ou do filtro de perguntas do jogo"},
+		{ErrRoundInProgress, "já há uma ronda a decorrer"},
+		{ErrRoundPaused, "a ronda atual está em pausa; retoma-a ou cancela-a primeiro"},
+		{ErrRoundFailed, "a ronda atual falhou; repete-a ou cancela-a primeiro"},
+		{ErrNoCurrentRound, "não há nenhuma ronda em curso"},
+		{ErrRoundNotFailed, "a ronda atual não falhou"},
+		{ErrAutoplayRunning, "o autoplay já está a correr neste jogo"},
+		{ErrAutoplayNotRunning, "o autoplay não está a correr neste jogo"},
+		{ErrInvalidQuestionSource, "fonte de perguntas inválida"},
+		{ErrNoPendingInput, "não há nenhuma intervenção pendente para este lugar"},
+		{ErrInvalidInput, "intervenção inválida"},
+		{ErrVotingClosed, "a votação do público não está aberta nesta ronda"},
+		{ErrAlreadyVoted, "esta sessão já votou nesta ronda"},
+		{ErrInvalidAudienceVote, "voto do público inválido"},
+		{ErrSessionRequired, "é precisa uma sessão do público; abre o jogo primeiro"},
+		{repository.ErrQuestionNotFound, "pergunta não encontrada"},
+		{service.ErrUnknownPromptSet, "set de prompts desconhecido"},
+		{repository.ErrTournamentNotFound, "torneio não encontrado"},
+		{ErrTournamentFinished, "o torneio já terminou"},
+		{ErrTournamentAdvancing, "o torneio já está a avançar"},
+		{ErrUnsupportedExportFormat, "formato não suportado, usa md, html ou jsonl"},
+	},
+}
+
+// rejectReasonMessages traduz os motivos de rejeição de uma pergunta.
+var rejectReasonMessages = map[domain.Language]map[string]string{
+	domain.LanguagePortuguese: {
+		RejectTooShort:        "a pergunta é demasiado curta",
+		RejectTooLong:         "a pergunta é demasiado longa",
+		RejectControlChars:    "a pergunta tem caracteres de controlo",
+		RejectPromptInjection: "a pergunta parece tentar mudar as regras do jogo",
+	},
+}
+
+// ErrorMessage devolve a mensagem do erro na língua do jogo. O detalhe que
+// vem depois do erro base (ex: "invalid input: text is required") é mantido.
+func ErrorMessage(err error, lang domain.Language) string {
+	var rejection *QuestionRejection
+	if errors.As(err, &rejection) && rejectReasonMessages[lang] != nil {
+		details := make([]string, 0, len(rejection.Reasons))
+		for _, reason := range rejection.Reasons {
+			if msg, ok := rejectReasonMessages[lang][reason.Code]; ok {
+				details = append(details, msg)
+			} else {
+				details = append(details, reason.Detail)
+			}
+		}
+		return ErrorMessage(ErrQuestionRejected, lang) + ": " + strings.Join(details, "; ")
+	}
+
+	for _, m := range errorMessages[lang] {
+		if !errors.Is(err, m.err) {
+			continue
+		}
+		if detail, ok := strings.CutPrefix(err.Error(), m.err.Error()); ok {
+			return m.msg + detail
+		}
+		return m.msg
+	}
+	return err.Error()
+}
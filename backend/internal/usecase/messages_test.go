@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/service"
+)
+
+func TestErrorMessage(t *testing.T) {
+	pt, en := domain.LanguagePortuguese, domain.LanguageEnglish
+
+	tests := []struct {
+		name string
+		err  error
+		lang domain.Language
+		want string
+	}{
+		{"erro base", ErrGameFinished, pt, "o jogo já terminou"},
+		{"mantém o detalhe", fmt.Errorf("%w: text is required", ErrInvalidInput), pt, "intervenção inválida: text is required"},
+		{
+			// Dois erros base: ganha sempre o primeiro da lista
+			name: "dois erros base",
+			err:  fmt.Errorf("%w: %w", ErrQuestionGeneration, fmt.Errorf("%w: pt/x", service.ErrUnknownPromptSet)),
+			lang: pt,
+			want: "não foi possível gerar uma pergunta: unknown prompt set: pt/x",
+		},
+		{
+			name: "configuração com detalhe",
+			err:  fmt.Errorf("%w: %w", ErrInvalidRoundConfig, errors.New("debate_turns must be between 0 and 10")),
+			lang: pt,
+			want: "configuração da ronda inválida: debate_turns must be between 0 and 10",
+		},
+		{"inglês", ErrGameFinished, en, "game already finished"},
+		{"formato de exportação", ErrUnsupportedExportFormat, pt, "formato não suportado, usa md, html ou jsonl"},
+		{"desconhecido", errors.New("boom"), pt, "boom"},
+		{
+			name: "pergunta recusada",
+			err:  &QuestionRejection{Reasons: []RejectReason{{Code: RejectTooShort, Detail: "too short"}, {Code: "outro", Detail: "other"}}},
+			lang: pt,
+			want: "pergunta recusada: a pergunta é demasiado curta; other",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repetido porque a ordem de um mapa mudava de execução para execução
+			for i := 0; i < 20; i++ {
+				if got := ErrorMessage(tt.err, tt.lang); got != tt.want {
+					t.Fatalf("ErrorMessage() = %q, want %q", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// Modos da moderação quando um texto gerado tem termos bloqueados.
+const (
+	ModerationModeRedact     = "redact"     // tapa os termos bloqueados
+	ModerationModeRegenerate = "regenerate" // pede outro texto ao LLM
+)
+
+// moderationRetries é quantas vezes um texto é gerado outra vez antes de a
+// moderação desistir e tapar (ou remover) o que sobrar.
+const moderationRetries = 2
+
+// redactedTerm substitui cada termo bloqueado.
+const redactedTerm = "***"
+
+// ModerationPolicy define como são moderados os textos gerados pelos agentes
+// (respostas, mensagens do debate, discursos da final e justificações).
+type ModerationPolicy struct {
+	Blocklist []string // termos proibidos, sem distinguir maiúsculas
+	Mode      string   // redact (omissão) ou regenerate
+	Classify  bool     // pedir também ao LLM para classificar cada texto
+}
+
+func (p ModerationPolicy) Validate() error {
+	switch p.Mode {
+	case "", ModerationModeRedact, ModerationModeRegenerate:
+		return nil
+	}
+	return fmt.Errorf("invalid moderation mode: %s", p.Mode)
+}
+
+// ReadBlocklist lê uma lista de termos, um por linha. Linhas vazias e
+// começadas por # são ignoradas.
+func ReadBlocklist(r io.Reader) ([]string, error) {
+	var terms []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		terms = append(terms, line)
+	}
+	return terms, sc.Err()
+}
+
+// moderator aplica a ModerationPolicy com a blocklist já compilada.
+type moderator struct {
+	policy    ModerationPolicy
+	blocklist *regexp.Regexp // nil sem termos
+}
+
+func newModerator(policy ModerationPolicy) *moderator {
+	m := &moderator{policy: policy}
+
+	terms := make([]string, 0, len(policy.Blocklist))
+	for _, t := range policy.Blocklist {
+		if t = strings.TrimSpace(t); t != "" {
+			terms = append(terms, regexp.QuoteMeta(t))
+		}
+	}
+	if len(terms) > 0 {
+		// Os termos mais compridos primeiro, para "x y" ganhar a "x"
+		sort.Slice(terms, func(i, j int) bool { return len(terms[i]) > len(terms[j]) })
+		// \b só conhece letras ASCII, por isso as fronteiras são feitas à mão
+		m.blocklist = regexp.MustCompile(`(?i)(^|[^\p{L}\p{N}])(` + strings.Join(terms, "|") + `)($|[^\p{L}\p{N}])`)
+	}
+	return m
+}
+
+func (m *moderator) enabled() bool {
+	return m.blocklist != nil || m.policy.Classify
+}
+
+// blocked devolve os termos bloqueados que aparecem no texto.
+func (m *moderator) blocked(text string) []string {
+	if m.blocklist == nil {
+		return nil
+	}
+	var found []string
+	seen := make(map[string]bool)
+	for _, match := range m.blocklist.FindAllStringSubmatch(text, -1) {
+		term := strings.ToLower(match[2])
+		if !seen[term] {
+			seen[term] = true
+			found = append(found, term)
+		}
+	}
+	return found
+}
+
+// redact tapa os termos bloqueados. Repete até não sobrar nenhum porque dois
+// termos seguidos partilham a fronteira e o segundo escapa à primeira passagem.
+func (m *moderator) redact(text string) string {
+	for m.blocklist.MatchString(text) {
+		text = m.blocklist.ReplaceAllString(text, "${1}"+redactedTerm+"${3}")
+	}
+	return text
+}
+
+// moderate verifica um texto gerado por um agente IA e devolve o texto a usar.
+// Com regenerate (só nos textos, não nas justificações) e o modo regenerate,
+// um texto com termos bloqueados é gerado outra vez; se continuar a tê-los, é
+// tapado. Um texto recusado pelo classificador é gerado outra vez (se possível)
+// ou removido. As ações ficam na ronda e são emitidas como evento moderation.
+// regenerate corre como uma intervenção (aiTurn), com o tempo máximo da fase:
+// se não houver texto novo a tempo, é moderado o que já havia.
+func (uc *PlayRoundUseCase) moderate(ctx context.Context, game *domain.Game, round *domain.Round, agentID string, phase domain.Phase, field string, text string, regenerate func(context.Context) (string, bool, error), emit RoundEventFunc) (string, error) {
+	m := uc.moderator
+	if !m.enabled() {
+		return text, nil
+	}
+	if m.policy.Mode != ModerationModeRegenerate {
+		regenerate = nil
+	}
+
+	// As ações só entram na ronda no fim, para um erro a meio (que faz a
+	// ronda falhar e ser repetida) não as deixar registadas duas vezes
+	var actions []domain.ModerationAction
+	record := func(action, reason string) {
+		actions = append(actions, domain.ModerationAction{
+			AgentID: agentID,
+			Phase:   phase,
+			Field:   field,
+			Action:  action,
+			Reason:  reason,
+		})
+	}
+
+	for attempt := 0; ; attempt++ {
+		reason, err := uc.flagged(ctx, game, text)
+		if err != nil {
+			return "", err
+		}
+		if reason == "" {
+			break
+		}
+
+		if regenerate != nil && attempt < moderationRetries {
+			regenerated, ok, err := regenerate(ctx)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				record(domain.ModerationRegenerated, reason)
+				text = regenerated
+				continue
+			}
+			regenerate = nil
+		}
+
+		if terms := m.blocked(text); len(terms) > 0 {
+			text = m.redact(text)
+			record(domain.ModerationRedacted, strings.Join(terms, ", "))
+			// Tapados os termos, o texto só é removido se o classificador o recusar
+			if reason, err = uc.flagged(ctx, game, text); err != nil {
+				return "", err
+			}
+			if reason == "" {
+				break
+			}
+		}
+		text = moderatedText(game.Lang())
+		record(domain.ModerationRemoved, reason)
+		break
+	}
+
+	for _, a := range actions {
+		round.Moderation = append(round.Moderation, a)
+		emit("moderation", a)
+	}
+	return text, nil
+}
+
+// flagged devolve porque é que o texto não pode ser mostrado ("" se pode):
+// os termos bloqueados que tem ou o motivo dado pelo classificador.
+func (uc *PlayRoundUseCase) flagged(ctx context.Context, game *domain.Game, text string) (string, error) {
+	if terms := uc.moderator.blocked(text); len(terms) > 0 {
+		return strings.Join(terms, ", "), nil
+	}
+	if !uc.moderator.policy.Classify {
+		return "", nil
+	}
+	allowed, reason, err := uc.groq.ClassifyMessage(ctx, game, text)
+	if err != nil {
+		return "", err
+	}
+	if allowed {
+		return "", nil
+	}
+	if reason == "" {
+		reason = "classifier"
+	}
+	return reason, nil
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+func TestModeratorRedact(t *testing.T) {
+	m := newModerator(ModerationPolicy{Blocklist: []string{"batata", "batata frita", " ", "Olá"}})
+
+	tests := []struct{ in, want string }{
+		{"gosto de batata", "gosto de ***"},
+		{"BATATA FRITA!", "***!"},
+		{"batata batata batata", "*** *** ***"},
+		{"olá, batatas", "***, batatas"},
+		{"batatada", "batatada"},
+		{"ólá e olá", "ólá e ***"},
+	}
+	for _, tt := range tests {
+		if got := m.redact(tt.in); got != tt.want {
+			t.Errorf("redact(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// slowRegenGroq responde uma vez a cada agente com um termo bloqueado e
+// bloqueia as respostas geradas outra vez pela moderação até o tempo da fase
+// acabar.
+type slowRegenGroq struct {
+	stepGroq
+	answered map[string]bool
+}
+
+func (g *slowRegenGroq) GenerateAnswer(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, error) {
+	if g.answered[agent.ID] {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}
+	g.answered[agent.ID] = true
+	return "resposta proibida de " + agent.ID, nil
+}
+
+func TestModerateRegenerateTimeout(t *testing.T) {
+	repo := repository.NewInMemoryGameRepository()
+	create := newTestCreateGame(t)
+	create.gameRepo = repo
+	out, err := create.Execute(CreateGameInput{NumAgents: 3, RoundConfig: domain.RoundConfig{
+		Timeouts: map[domain.Phase]int{domain.PhaseAnswer: 1},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := ModerationPolicy{Blocklist: []string{"proibida"}, Mode: ModerationModeRegenerate}
+	uc := NewPlayRoundUseCase(repo, &slowRegenGroq{stepGroq: stepGroq{budget: 1 << 20}, answered: make(map[string]bool)}, NewQuestionBankUseCase(repository.NewInMemoryQuestionRepository()), QuestionPolicy{}, policy, nil)
+	var timeouts int
+	_, err = uc.Execute(context.Background(), PlayRoundInput{GameID: out.Game.ID, Question: stepQuestion, OnEvent: func(event string, payload any) {
+		if event == "input_timeout" {
+			timeouts++
+		}
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if timeouts != 3 {
+		t.Errorf("input_timeout %d vezes, want 3", timeouts)
+	}
+	game, _ := repo.Get(out.Game.ID)
+	round := game.Rounds[0]
+	for _, a := range round.Answers {
+		if want := "resposta *** de " + a.AgentID; a.Text != want {
+			t.Errorf("resposta %q, want %q", a.Text, want)
+		}
+	}
+	for _, a := range round.Moderation {
+		if a.Phase == domain.PhaseAnswer && a.Action != domain.ModerationRedacted {
+			t.Errorf("moderação da resposta de %s: %s, want %s", a.AgentID, a.Action, domain.ModerationRedacted)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported format, use json or yaml")
+
+// PackFormat é o formato de ficheiro dos packs de personas.
+type PackFormat string
+
+const (
+	PackFormatJSON PackFormat = "json"
+	PackFormatYAML PackFormat = "yaml"
+)
+
+// PersonaLibraryUseCase gere a biblioteca de personas reutilizáveis entre jogos.
+type PersonaLibraryUseCase struct {
+	personaRepo repository.PersonaRepository
+}
+
+func NewPersonaLibraryUseCase(repo repository.PersonaRepository) *PersonaLibraryUseCase {
+	return &PersonaLibraryUseCase{personaRepo: repo}
+}
+
+func validatePersona(p *domain.Persona) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return fmt.Errorf("persona name is required")
+	}
+	return nil
+}
+
+func (uc *PersonaLibraryUseCase) Create(p domain.Persona) (*domain.Persona, error) {
+	if err := validatePersona(&p); err != nil {
+		return nil, err
+	}
+	p.ID = uuid.NewString()
+	if err := uc.personaRepo.Create(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (uc *PersonaLibraryUseCase) Update(id string, p domain.Persona) (*domain.Persona, error) {
+	if err := validatePersona(&p); err != nil {
+		return nil, err
+	}
+	p.ID = id
+	if err := uc.personaRepo.Update(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (uc *PersonaLibraryUseCase) Delete(id string) error {
+	return uc.personaRepo.Delete(id)
+}
+
+// Import lê um pack de personas. Personas com um ID que já existe são
+// atualizadas; as restantes são criadas com um ID novo.
+func (uc *PersonaLibraryUseCase) Import(data []byte, format PackFormat) ([]*domain.Persona, error) {
+	var pack domain.PersonaPack
+	switch format {
+	case PackFormatJSON:
+		if err := json.Unmarshal(data, &pack); err != nil {
+			return nil, fmt.Errorf("invalid json pack: %w", err)
+		}
+	case PackFormatYAML:
+		if err := yaml.Unmarshal(data, &pack); err != nil {
+			return nil, fmt.Errorf("invalid yaml pack: %w", err)
+		}
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	// Validar tudo antes de gravar para um pack inválido não ficar a meio
+	for i := range pack.Personas {
+		if err := validatePersona(&pack.Personas[i]); err != nil {
+			return nil, fmt.Errorf("persona %d: %w", i+1, err)
+		}
+	}
+
+	res := make([]*domain.Persona, 0, len(pack.Personas))
+	for _, p := range pack.Personas {
+		if p.ID != "" {
+			if _, err := uc.personaRepo.Get(p.ID); err == nil {
+				if err := uc.personaRepo.Update(&p); err != nil {
+					return nil, err
+				}
+				res = append(res, &p)
+				continue
+			}
+		}
+		p.ID = uuid.NewString()
+		if err := uc.personaRepo.Create(&p); err != nil {
+			return nil, err
+		}
+		res = append(res, &p)
+	}
+	return res, nil
+}
+
+// Export serializa a biblioteca toda (ou só os IDs pedidos) como um pack.
+func (uc *PersonaLibraryUseCase) Export(ids []string, format PackFormat) ([]byte, error) {
+	pack := domain.PersonaPack{Name: "ai-hunger-games"}
+
+	if len(ids) == 0 {
+		personas, err := uc.personaRepo.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range personas {
+			pack.Personas = append(pack.Personas, *p)
+		}
+	} else {
+		for _, id := range ids {
+			p, err := uc.personaRepo.Get(id)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", err, id)
+			}
+			pack.Personas = append(pack.Personas, *p)
+		}
+	}
+
+	switch format {
+	case PackFormatJSON:
+		return json.MarshalIndent(pack, "", "  ")
+	case PackFormatYAML:
+		return yaml.Marshal(pack)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
@@ -2,16 +2,38 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/rafawastaken/ai-hunger-games/internal/domain"
 	"github.com/rafawastaken/ai-hunger-games/internal/repository"
 	"github.com/rafawastaken/ai-hunger-games/internal/service"
 )
 
+var (
+	ErrGameFinished       = errors.New("game already finished")
+	ErrQuestionRequired   = errors.New("question is required")
+	ErrNoActiveAgents     = errors.New("no active agents in game")
+	ErrInvalidRoundConfig = errors.New("invalid round config")
+	ErrQuestionGeneration = errors.New("could not generate a question")
+	ErrQuestionMismatch   = errors.New("question does not match question_id")
+	ErrQuestionNotAllowed = errors.New("question_id is not in the game's language or question_filter")
+)
+
+// RoundEventFunc recebe os eventos da ronda à medida que acontecem
+// (answer, debate, vote, phase, judge_vote, round_end, game_end...).
+type RoundEventFunc func(event string, payload any)
+
 type PlayRoundInput struct {
-	GameID   string
-	Question string
+	GameID       string
+	Question     string
+	QuestionID   string              // pergunta do banco; a pergunta é o texto dela se Question vier vazia
+	AutoQuestion bool                // sem pergunta: o apresentador (LLM) gera uma
+	Config       *domain.RoundConfig // opcional, altera a estrutura só desta ronda
+	OnEvent      RoundEventFunc      // opcional, usado pelo streaming SSE
 }
 
 type PlayRoundOutput struct {
@@ -19,17 +41,48 @@ type PlayRoundOutput struct {
 	Round *domain.Round
 }
 
+type questionPayload struct {
+	Round    int    `json:"round"`
+	Question string `json:"question"`
+}
+
+type roundEndPayload struct {
+	Game  *domain.Game  `json:"game"`
+	Round *domain.Round `json:"round"`
+}
+
+type gameEndPayload struct {
+	WinnerID  string            `json:"winner_id"`
+	EndReason domain.EndReason  `json:"end_reason"`
+	Standings []domain.Standing `json:"standings"`
+	Game      *domain.Game      `json:"game"`
+}
+
 type PlayRoundUseCase struct {
-	gameRepo    repository.GameRepository
-	groq        service.GroqService
-	debateTurns int
+	gameRepo       repository.GameRepository
+	groq           service.GroqService
+	questions      *QuestionBankUseCase
+	questionPolicy QuestionPolicy
+	moderator      *moderator
+	ratings        *RatingUseCase
+	humans         *HumanInputHub
+	audience       *AudienceBox
+
+	runsMu sync.Mutex
+	runs   map[string]context.CancelCauseFunc // jogo -> ronda em curso
 }
 
-func NewPlayRoundUseCase(repo repository.GameRepository, groq service.GroqService) *PlayRoundUseCase {
+func NewPlayRoundUseCase(repo repository.GameRepository, groq service.GroqService, questions *QuestionBankUseCase, questionPolicy QuestionPolicy, moderation ModerationPolicy, ratings *RatingUseCase) *PlayRoundUseCase {
 	return &PlayRoundUseCase{
-		gameRepo:    repo,
-		groq:        groq,
-		debateTurns: 2, // reduzido para economizar tokens
+		gameRepo:       repo,
+		groq:           groq,
+		questions:      questions,
+		questionPolicy: questionPolicy,
+		moderator:      newModerator(moderation),
+		ratings:        ratings,
+		humans:         NewHumanInputHub(),
+		audience:       NewAudienceBox(),
+		runs:           make(map[string]context.CancelCauseFunc),
 	}
 }
 
@@ -39,111 +92,512 @@ func (uc *PlayRoundUseCase) Execute(ctx context.Context, input PlayRoundInput) (
 		return nil, err
 	}
 	if game.Status == domain.GameStatusFinished {
-		return nil, fmt.Errorf("game already finished")
+		return nil, ErrGameFinished
 	}
-	if input.Question == "" {
-		return nil, fmt.Errorf("question is required")
+	if game.CurrentRound != nil {
+		return nil, uc.currentRoundError(game)
+	}
+	if input.QuestionID != "" {
+		q, err := uc.questions.Get(input.QuestionID)
+		if err != nil {
+			return nil, err
+		}
+		// O texto, se vier, tem de ser o da pergunta do banco, para a ronda
+		// não registar uma pergunta e contar o uso de outra
+		if text := strings.TrimSpace(input.Question); text != "" && text != q.Text {
+			return nil, ErrQuestionMismatch
+		}
+		if !game.AllowsQuestion(q) {
+			return nil, ErrQuestionNotAllowed
+		}
+		input.Question = q.Text
+	}
+	autoQuestion := input.AutoQuestion && strings.TrimSpace(input.Question) == ""
+	if strings.TrimSpace(input.Question) == "" && !autoQuestion {
+		return nil, ErrQuestionRequired
+	}
+	if input.Config != nil {
+		if err := input.Config.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRoundConfig, err)
+		}
 	}
 
-	activeAgents := game.ActiveAgents()
-	if len(activeAgents) == 0 {
-		return nil, fmt.Errorf("no active agents in game")
+	if len(game.ActiveAgents()) == 0 {
+		return nil, ErrNoActiveAgents
+	}
+
+	// Falha aqui (antes de qualquer evento) se o set de prompts do jogo não existir
+	promptVersion, err := uc.groq.PromptVersion(game)
+	if err != nil {
+		return nil, err
+	}
+
+	if autoQuestion {
+		if input.Question, err = uc.groq.GenerateQuestion(ctx, game); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrQuestionGeneration, err)
+		}
+	} else if input.Question, err = uc.checkQuestion(ctx, game, input.Question); err != nil {
+		return nil, err
 	}
 
 	round := &domain.Round{
-		Index:    game.NextRoundIndex(),
-		Question: input.Question,
+		Index:         game.NextRoundIndex(),
+		Question:      input.Question,
+		QuestionID:    input.QuestionID,
+		AutoQuestion:  autoQuestion,
+		PromptVersion: promptVersion,
+		Config:        game.RoundConfig.Merge(input.Config),
 	}
 
-	// 1) Respostas iniciais
-	for _, agent := range activeAgents {
-		text, err := uc.groq.GenerateAnswer(ctx, game, agent, input.Question)
-		if err != nil {
-			return nil, err
+	if autoQuestion && input.OnEvent != nil {
+		input.OnEvent("question", questionPayload{Round: round.Index, Question: round.Question})
+	}
+	return uc.run(ctx, game, round, input.OnEvent)
+}
+
+// run joga a ronda (nova ou retomada) até ao fim, ou até ser posta em pausa
+// ou cancelada.
+func (uc *PlayRoundUseCase) run(ctx context.Context, game *domain.Game, round *domain.Round, emit RoundEventFunc) (*PlayRoundOutput, error) {
+	if emit == nil {
+		emit = func(string, any) {}
+	}
+
+	runCtx, release, err := uc.startRun(ctx, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	round.State = domain.RoundStateRunning
+	round.Error = ""
+	game.CurrentRound = round
+	if err := uc.gameRepo.Update(game); err != nil {
+		return nil, err
+	}
+
+	activeAgents := game.ActiveAgents()
+	if game.IsFinale() {
+		err = uc.playFinale(runCtx, game, round, activeAgents, emit)
+	} else {
+		err = uc.playRegular(runCtx, game, round, activeAgents, emit)
+	}
+	if err != nil {
+		return uc.interrupted(runCtx, game, round, err, emit)
+	}
+
+	// Atualizar estado do jogo
+	round.State = ""
+	round.Cursor = nil
+	game.CurrentRound = nil
+	game.Rounds = append(game.Rounds, round)
+	if round.QuestionID != "" {
+		// A pergunta pode ter sido apagada do banco entretanto
+		_ = uc.questions.MarkUsed(round.QuestionID)
+	}
+
+	if len(game.ActiveAgents()) <= 1 {
+		game.Finish()
+		now := time.Now().UTC()
+		game.FinishedAt = &now
+	} else {
+		game.Status = domain.GameStatusRunning
+	}
+
+	if err := uc.gameRepo.Update(game); err != nil {
+		return nil, err
+	}
+
+	emit("round_end", roundEndPayload{Game: game, Round: round})
+	if game.Status == domain.GameStatusFinished {
+		if uc.ratings != nil {
+			// As classificações podem sempre ser recalculadas a partir dos jogos
+			_ = uc.ratings.Record(game)
 		}
-		round.Answers = append(round.Answers, domain.Answer{
-			AgentID: agent.ID,
-			Text:    text,
+		emit("game_end", gameEndPayload{
+			WinnerID:  game.WinnerID,
+			EndReason: game.EndReason,
+			Standings: game.Standings,
+			Game:      game,
 		})
 	}
 
-	// 2) Debate
-	for turn := 1; turn <= uc.debateTurns; turn++ {
-		for _, agent := range activeAgents {
-			msg, err := uc.groq.GenerateDebateMessage(ctx, game, round, agent)
+	return &PlayRoundOutput{
+		Game:  game,
+		Round: round,
+	}, nil
+}
+
+func (uc *PlayRoundUseCase) playRegular(ctx context.Context, game *domain.Game, round *domain.Round, activeAgents []*domain.Agent, emit RoundEventFunc) error {
+	steps := newRoundSteps(ctx, round, func() error {
+		return uc.gameRepo.Update(game)
+	})
+
+	// 1) Respostas iniciais
+	for _, agent := range speakingOrder(game, round, domain.PhaseAnswer, activeAgents) {
+		err := steps.do("answer", func() error {
+			text, err := uc.answer(ctx, game, round, agent, emit)
 			if err != nil {
-				return nil, err
+				return err
 			}
-			round.Debate = append(round.Debate, domain.DebateMessage{
+			ans := domain.Answer{
 				AgentID: agent.ID,
-				Turn:    turn,
-				Text:    msg,
+				Text:    text,
+			}
+			round.Answers = append(round.Answers, ans)
+			emit("answer", ans)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := steps.do("answer", func() error {
+		emit("phase", map[string]string{"phase": "answers_done"})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 2) Debate (a mesma ordem em todos os turnos; 0 turnos se estiver desligado)
+	debateTurns := round.Config.Turns()
+	var debateOrder []*domain.Agent
+	if debateTurns > 0 {
+		debateOrder = speakingOrder(game, round, domain.PhaseDebate, activeAgents)
+	}
+	for turn := 1; turn <= debateTurns; turn++ {
+		err := steps.do("debate", func() error {
+			return uc.compactDebate(ctx, game, round, turn, emit)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, agent := range debateOrder {
+			err := steps.do("debate", func() error {
+				msg, ok, err := uc.debateMessage(ctx, game, round, agent, emit)
+				if err != nil || !ok {
+					return err
+				}
+				dm := domain.DebateMessage{
+					AgentID: agent.ID,
+					Turn:    turn,
+					Text:    msg,
+				}
+				round.Debate = append(round.Debate, dm)
+				emit("debate", dm)
+				return nil
 			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
+	// O anúncio de cada fase é um passo à parte, para não ser repetido quando
+	// o passo seguinte falha e a ronda é repetida
+	err = steps.do("debate", func() error {
+		emit("phase", map[string]string{"phase": "debate_done"})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = steps.do("debate", func() error {
+		return uc.compactDebate(ctx, game, round, debateTurns+1, emit)
+	})
+	if err != nil {
+		return err
+	}
+
 	// 3) Votação
-	votesCount := make(map[string]int)
+	for _, agent := range speakingOrder(game, round, domain.PhaseVote, activeAgents) {
+		err := steps.do("vote", func() error {
+			targetID, justification, ok, err := uc.vote(ctx, game, round, agent, activeAgents, emit)
+			if err != nil || !ok {
+				return err
+			}
+			v := domain.Vote{
+				VoterID:       agent.ID,
+				TargetID:      targetID,
+				Justification: justification,
+			}
+			round.Votes = append(round.Votes, v)
+			emit("vote", v)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
 
-	// inicializar todos a 0 para zeros também contarem como pior score
-	for _, agent := range activeAgents {
-		votesCount[agent.ID] = 0
+	// 4) Votação do público (opcional), misturada com os votos dos agentes
+	if game.AudienceWindow > 0 {
+		err := steps.do("audience", func() error {
+			var targets []string
+			for _, agent := range activeAgents {
+				targets = append(targets, agent.ID)
+			}
+			tally, err := uc.collectAudienceVotes(ctx, game, round, targets, emit)
+			if err != nil {
+				return err
+			}
+			round.Audience = &domain.AudienceResult{
+				Tally:  tally,
+				Weight: game.AudienceWeight,
+				Scores: blendAudienceVotes(countVotes(round, activeAgents), tally, game.AudienceWeight),
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	for _, agent := range activeAgents {
-		targetID, justification, err := uc.groq.GenerateVote(ctx, game, round, agent)
+	// 5) Strike, com o juiz a desempatar. Os empatados saem dos votos já
+	// guardados, por isso são os mesmos quando a ronda é retomada.
+	tiedAgents := strikeCandidates(round, activeAgents)
+	if len(tiedAgents) > 1 {
+		err := steps.do("judge", func() error {
+			emit("phase", map[string]string{"phase": "judge"})
+			return nil
+		})
 		if err != nil {
-			return nil, err
+			return err
 		}
-		round.Votes = append(round.Votes, domain.Vote{
-			VoterID:       agent.ID,
-			TargetID:      targetID,
-			Justification: justification,
+		err = steps.do("judge", func() error {
+			return uc.judgeTie(ctx, game, round, tiedAgents, emit)
 		})
-		if _, ok := votesCount[targetID]; ok {
-			votesCount[targetID]++
+		if err != nil {
+			return err
 		}
 	}
 
-	// 4) Determinar quem levou strike (MAIS votos = pior resposta)
-	if len(activeAgents) > 0 {
-		maxVotes := 0
-		for _, agent := range activeAgents {
-			count := votesCount[agent.ID]
-			if count > maxVotes {
-				maxVotes = count
-			}
+	return steps.do("strike", func() error {
+		applyStrike(game, round, tiedAgents)
+		return nil
+	})
+}
+
+// countVotes conta os votos recebidos por cada agente ativo (todos começam
+// a 0 para zeros também contarem como pior score).
+func countVotes(round *domain.Round, activeAgents []*domain.Agent) map[string]int {
+	votesCount := make(map[string]int, len(activeAgents))
+	for _, agent := range activeAgents {
+		votesCount[agent.ID] = 0
+	}
+	for _, v := range round.Votes {
+		if _, ok := votesCount[v.TargetID]; ok {
+			votesCount[v.TargetID]++
 		}
+	}
+	return votesCount
+}
 
-		// Só dá strike se alguém recebeu pelo menos 1 voto
-		if maxVotes > 0 {
-			for _, agent := range activeAgents {
-				if votesCount[agent.ID] == maxVotes {
-					agent.Strikes++
-					if agent.Strikes >= game.MaxStrikes {
-						agent.Eliminated = true
-						round.Eliminated = append(round.Eliminated, agent.ID)
-					}
-				}
-			}
+// strikeCandidates devolve os agentes com a pior pontuação da ronda (MAIS
+// votos = pior resposta). Mais de um é um empate para o juiz; nenhum quando
+// ninguém recebeu votos.
+func strikeCandidates(round *domain.Round, activeAgents []*domain.Agent) []string {
+	var scores map[string]float64
+	if round.Audience != nil {
+		scores = round.Audience.Scores
+	} else {
+		votesCount := countVotes(round, activeAgents)
+		scores = make(map[string]float64, len(votesCount))
+		for id, count := range votesCount {
+			scores[id] = float64(count)
 		}
 	}
 
-	// 5) Atualizar estado do jogo
-	game.Rounds = append(game.Rounds, round)
+	maxScore := 0.0
+	for _, agent := range activeAgents {
+		if score := scores[agent.ID]; score > maxScore {
+			maxScore = score
+		}
+	}
 
-	if len(game.ActiveAgents()) <= 1 {
-		game.Status = domain.GameStatusFinished
+	// Só dá strike se alguém recebeu pelo menos 1 voto
+	if maxScore == 0 {
+		return nil
+	}
+
+	// Encontrar todos os empatados com a pontuação máxima
+	var tiedAgents []string
+	for _, agent := range activeAgents {
+		if scores[agent.ID] == maxScore {
+			tiedAgents = append(tiedAgents, agent.ID)
+		}
+	}
+	return tiedAgents
+}
+
+// judgeTie chama o Juiz para escolher qual dos empatados leva o strike.
+func (uc *PlayRoundUseCase) judgeTie(ctx context.Context, game *domain.Game, round *domain.Round, tiedAgents []string, emit RoundEventFunc) error {
+	targetID, justification, err := uc.groq.GenerateJudgeVote(ctx, game, round, tiedAgents)
+	if err != nil {
+		return err
+	}
+	justification, err = uc.moderate(ctx, game, round, "judge", domain.PhaseVote, "justification", justification, nil, emit)
+	if err != nil {
+		return err
+	}
+	round.Judge = &domain.JudgeDecision{
+		TiedAgents:    tiedAgents,
+		TargetID:      targetID,
+		Justification: justification,
+	}
+
+	emit("judge_vote", map[string]interface{}{
+		"target_id":     targetID,
+		"justification": justification,
+		"tied_agents":   tiedAgents,
+	})
+	return nil
+}
+
+// applyStrike dá o strike ao único candidato ou ao escolhido pelo juiz.
+func applyStrike(game *domain.Game, round *domain.Round, tiedAgents []string) {
+	var strikeTarget string
+	switch {
+	case round.Judge != nil:
+		strikeTarget = round.Judge.TargetID
+	case len(tiedAgents) > 0:
+		strikeTarget = tiedAgents[0]
+	}
+
+	if agent := game.Agent(strikeTarget); agent != nil {
+		round.StrikeID = agent.ID
+		agent.Strikes++
+		if agent.Strikes >= game.MaxStrikes {
+			game.Eliminate(agent, round)
+		}
+	}
+}
+
+// speakingOrder ordena os agentes segundo a estratégia da fase e regista a
+// ordem na ronda, para a ronda poder ser reproduzida.
+func speakingOrder(game *domain.Game, round *domain.Round, phase domain.Phase, agents []*domain.Agent) []*domain.Agent {
+	ordered := game.OrderAgents(phase, round.Index, agents)
+	if round.SpeakingOrder == nil {
+		round.SpeakingOrder = make(map[domain.Phase][]string)
+	}
+	ids := make([]string, 0, len(ordered))
+	for _, a := range ordered {
+		ids = append(ids, a.ID)
+	}
+	round.SpeakingOrder[phase] = ids
+	return ordered
+}
+
+// answer, debateMessage e vote pedem a intervenção ao LLM ou, nos lugares
+// humanos, esperam pela submissão do jogador. ok=false quando o humano não
+// respondeu a tempo e a intervenção deve ser ignorada.
+
+func (uc *PlayRoundUseCase) answer(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, emit RoundEventFunc) (string, error) {
+	var text string
+	var ok bool
+	var err error
+	if !agent.Human {
+		generate := func(ctx context.Context) (string, bool, error) {
+			return aiTurn(ctx, round, agent, domain.PhaseAnswer, emit, func(ctx context.Context) (string, error) {
+				return uc.groq.GenerateAnswer(ctx, game, round, agent)
+			})
+		}
+		text, ok, err = generate(ctx)
+		if ok {
+			text, err = uc.moderate(ctx, game, round, agent.ID, domain.PhaseAnswer, "text", text, generate, emit)
+		}
 	} else {
-		game.Status = domain.GameStatusRunning
+		var in HumanInput
+		in, ok, err = uc.awaitHuman(ctx, game, round, agent, domain.PhaseAnswer, validateHumanText, emit)
+		text = in.Text
+	}
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return noAnswerText(game.Lang()), nil
 	}
+	return limitWords(text, round.Config.MaxAnswerWords), nil
+}
 
-	if err := uc.gameRepo.Update(game); err != nil {
-		return nil, err
+func (uc *PlayRoundUseCase) debateMessage(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, emit RoundEventFunc) (string, bool, error) {
+	if !agent.Human {
+		generate := func(ctx context.Context) (string, bool, error) {
+			return aiTurn(ctx, round, agent, domain.PhaseDebate, emit, func(ctx context.Context) (string, error) {
+				return uc.groq.GenerateDebateMessage(ctx, game, round, agent)
+			})
+		}
+		text, ok, err := generate(ctx)
+		if ok {
+			text, err = uc.moderate(ctx, game, round, agent.ID, domain.PhaseDebate, "text", text, generate, emit)
+		}
+		return limitWords(text, round.Config.MaxDebateWords), ok && err == nil, err
 	}
 
-	return &PlayRoundOutput{
-		Game:  game,
-		Round: round,
-	}, nil
+	in, ok, err := uc.awaitHuman(ctx, game, round, agent, domain.PhaseDebate, validateHumanText, emit)
+	return limitWords(in.Text, round.Config.MaxDebateWords), ok, err
+}
+
+func (uc *PlayRoundUseCase) vote(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, activeAgents []*domain.Agent, emit RoundEventFunc) (string, string, bool, error) {
+	if !agent.Human {
+		var ballot *domain.BlindBallot
+		if game.BlindVoting {
+			b := game.BlindBallot(round, agent)
+			ballot = &b
+		}
+		v, ok, err := aiTurn(ctx, round, agent, domain.PhaseVote, emit, func(ctx context.Context) (domain.Vote, error) {
+			targetID, justification, err := uc.groq.GenerateVote(ctx, game, round, agent, ballot)
+			return domain.Vote{TargetID: targetID, Justification: justification}, err
+		})
+		if ok {
+			v.Justification, err = uc.moderate(ctx, game, round, agent.ID, domain.PhaseVote, "justification", v.Justification, nil, emit)
+			ok = err == nil
+		}
+		// O boletim só fica registado quando o voto conta, para uma ronda
+		// retomada não o registar duas vezes
+		if ballot != nil && ok {
+			round.BlindBallots = append(round.BlindBallots, *ballot)
+		}
+		return v.TargetID, v.Justification, ok, err
+	}
+
+	validate := validateHumanVote(humanVoteTargets(activeAgents, agent))
+	in, ok, err := uc.awaitHuman(ctx, game, round, agent, domain.PhaseVote, validate, emit)
+	return in.TargetID, strings.TrimSpace(in.Justification), ok, err
+}
+
+// aiTurn corre a intervenção de um agente IA com o tempo máximo da fase. Se
+// esse tempo acabar (e não o da ronda), a intervenção é ignorada (ok=false)
+// como a de um humano que não respondeu a tempo.
+func aiTurn[T any](ctx context.Context, round *domain.Round, agent *domain.Agent, phase domain.Phase, emit RoundEventFunc, call func(context.Context) (T, error)) (T, bool, error) {
+	timeout := round.Config.Timeout(phase)
+	if timeout <= 0 {
+		res, err := call(ctx)
+		return res, err == nil, err
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res, err := call(callCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		emit("input_timeout", map[string]interface{}{
+			"agent_id": agent.ID,
+			"phase":    phase,
+		})
+		var zero T
+		return zero, false, nil
+	}
+	return res, err == nil, err
+}
+
+// limitWords corta o texto em n palavras (n <= 0 = sem limite).
+func limitWords(text string, n int) string {
+	words := strings.Fields(text)
+	if n <= 0 || len(words) <= n {
+		return text
+	}
+	return strings.Join(words[:n], " ") + "…"
 }
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+func TestPlayRoundQuestionID(t *testing.T) {
+	gameRepo := repository.NewInMemoryGameRepository()
+	questions := NewQuestionBankUseCase(repository.NewInMemoryQuestionRepository())
+	create := newTestCreateGame(t)
+	create.gameRepo = gameRepo
+
+	ethics, _ := questions.Create(domain.Question{Text: "É aceitável mentir?", Category: "ética"})
+	english, _ := questions.Create(domain.Question{Text: "Is it ok to lie?", Language: domain.LanguageEnglish})
+	science, _ := questions.Create(domain.Question{Text: "Marte ou a Lua?", Category: "ciência"})
+
+	tests := []struct {
+		name     string
+		id       string
+		question string
+		want     error
+	}{
+		{"texto diferente", ethics.ID, "Outra pergunta?", ErrQuestionMismatch},
+		{"outra língua", english.ID, "", ErrQuestionNotAllowed},
+		{"fora do filtro", science.ID, "", ErrQuestionNotAllowed},
+		{"não existe", "nope", "", repository.ErrQuestionNotFound},
+		{"mesmo texto", ethics.ID, "  É aceitável mentir? ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := create.Execute(CreateGameInput{NumAgents: 2, QuestionFilter: &domain.QuestionFilter{Category: "ética"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			uc := NewPlayRoundUseCase(gameRepo, &stepGroq{budget: 1 << 20}, questions, QuestionPolicy{}, ModerationPolicy{}, nil)
+			_, err = uc.Execute(context.Background(), PlayRoundInput{GameID: out.Game.ID, QuestionID: tt.id, Question: tt.question})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Execute() err = %v, want %v", err, tt.want)
+			}
+			if err != nil {
+				return
+			}
+			game, _ := gameRepo.Get(out.Game.ID)
+			if r := game.Rounds[0]; r.Question != ethics.Text || r.QuestionID != ethics.ID {
+				t.Errorf("ronda com %q (%s), want %q (%s)", r.Question, r.QuestionID, ethics.Text, ethics.ID)
+			}
+			if q, _ := questions.Get(ethics.ID); q.TimesUsed != 1 {
+				t.Errorf("TimesUsed = %d, want 1", q.TimesUsed)
+			}
+		})
+	}
+}
+
+// A ordem usada fica registada na ronda, e com a mesma semente é a mesma.
+func TestSpeakingOrder(t *testing.T) {
+	newGame := func() *domain.Game {
+		g := &domain.Game{Seed: 9, SpeakingOrder: domain.SpeakingOrder{domain.PhaseDebate: domain.OrderShuffle}}
+		for _, id := range []string{"agent-1", "agent-2", "agent-3", "agent-4", "agent-5"} {
+			g.Agents = append(g.Agents, &domain.Agent{ID: id})
+		}
+		return g
+	}
+
+	g := newGame()
+	round := &domain.Round{Index: 2}
+	ordered := speakingOrder(g, round, domain.PhaseDebate, g.Agents)
+	var ids []string
+	for _, a := range ordered {
+		ids = append(ids, a.ID)
+	}
+	if !slices.Equal(round.SpeakingOrder[domain.PhaseDebate], ids) {
+		t.Errorf("ordem registada %v, want %v", round.SpeakingOrder[domain.PhaseDebate], ids)
+	}
+
+	other := newGame()
+	again := &domain.Round{Index: 2}
+	speakingOrder(other, again, domain.PhaseDebate, other.Agents)
+	if !slices.Equal(again.SpeakingOrder[domain.PhaseDebate], ids) {
+		t.Errorf("a mesma semente deu %v, want %v", again.SpeakingOrder[domain.PhaseDebate], ids)
+	}
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+var ErrUnsupportedQuestionFormat = errors.New("unsupported format, use json or csv")
+
+// QuestionFormat é o formato dos ficheiros de perguntas importados.
+type QuestionFormat string
+
+const (
+	QuestionFormatJSON QuestionFormat = "json"
+	QuestionFormatCSV  QuestionFormat = "csv"
+)
+
+// QuestionBankUseCase gere o banco de perguntas partilhado entre jogos.
+type QuestionBankUseCase struct {
+	questionRepo repository.QuestionRepository
+
+	// usedMu serializa as escritas que leem e gravam as contagens de uso
+	// (MarkUsed e Update), para jogos em paralelo não perderem utilizações
+	usedMu sync.Mutex
+}
+
+func NewQuestionBankUseCase(repo repository.QuestionRepository) *QuestionBankUseCase {
+	return &QuestionBankUseCase{questionRepo: repo}
+}
+
+func validateQuestion(q *domain.Question) error {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" {
+		return fmt.Errorf("question text is required")
+	}
+	if q.Language != "" && !q.Language.Valid() {
+		return fmt.Errorf("invalid language: %s", q.Language)
+	}
+	if q.Difficulty != "" && !q.Difficulty.Valid() {
+		return fmt.Errorf("invalid difficulty: %s", q.Difficulty)
+	}
+	q.Category = strings.TrimSpace(q.Category)
+	var tags []string
+	for _, t := range q.Tags {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	q.Tags = tags
+	return nil
+}
+
+// Seed enche o banco com as perguntas embutidas (domain.DefaultQuestions) se
+// estiver vazio. Devolve quantas perguntas criou.
+func (uc *QuestionBankUseCase) Seed() (int, error) {
+	questions, err := uc.questionRepo.List()
+	if err != nil || len(questions) > 0 {
+		return 0, err
+	}
+	defaults := domain.DefaultQuestions()
+	for _, q := range defaults {
+		if _, err := uc.Create(q); err != nil {
+			return 0, err
+		}
+	}
+	return len(defaults), nil
+}
+
+// List devolve as perguntas do banco que passam no filtro.
+func (uc *QuestionBankUseCase) List(filter *domain.QuestionFilter, lang domain.Language) ([]*domain.Question, error) {
+	questions, err := uc.questionRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*domain.Question, 0, len(questions))
+	for _, q := range questions {
+		if (lang == "" || q.Lang() == lang) && filter.Matches(q) {
+			res = append(res, q)
+		}
+	}
+	return res, nil
+}
+
+func (uc *QuestionBankUseCase) Create(q domain.Question) (*domain.Question, error) {
+	if err := validateQuestion(&q); err != nil {
+		return nil, err
+	}
+	q.ID = uuid.NewString()
+	q.TimesUsed = 0
+	q.LastUsedAt = nil
+	if err := uc.questionRepo.Create(&q); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// Update altera a pergunta, mantendo as estatísticas de uso.
+func (uc *QuestionBankUseCase) Update(id string, q domain.Question) (*domain.Question, error) {
+	if err := validateQuestion(&q); err != nil {
+		return nil, err
+	}
+
+	uc.usedMu.Lock()
+	defer uc.usedMu.Unlock()
+
+	old, err := uc.questionRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	q.ID = id
+	q.TimesUsed = old.TimesUsed
+	q.LastUsedAt = old.LastUsedAt
+	if err := uc.questionRepo.Update(&q); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+func (uc *QuestionBankUseCase) Delete(id string) error {
+	return uc.questionRepo.Delete(id)
+}
+
+// Import cria as perguntas de um ficheiro JSON ({"questions": [...]} ou só a
+// lista) ou CSV (com cabeçalho: text,category,tags,difficulty,language; as
+// tags separadas por ";").
+func (uc *QuestionBankUseCase) Import(data []byte, format QuestionFormat) ([]*domain.Question, error) {
+	var questions []domain.Question
+	switch format {
+	case QuestionFormatJSON:
+		var pack struct {
+			Questions []domain.Question `json:"questions"`
+		}
+		if err := json.Unmarshal(data, &pack); err != nil {
+			if err := json.Unmarshal(data, &questions); err != nil {
+				return nil, fmt.Errorf("invalid json: %w", err)
+			}
+		} else {
+			questions = pack.Questions
+		}
+	case QuestionFormatCSV:
+		var err error
+		if questions, err = parseQuestionsCSV(data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedQuestionFormat
+	}
+
+	// Validar tudo antes de gravar para um ficheiro inválido não ficar a meio
+	for i := range questions {
+		if err := validateQuestion(&questions[i]); err != nil {
+			return nil, fmt.Errorf("question %d: %w", i+1, err)
+		}
+	}
+
+	res := make([]*domain.Question, 0, len(questions))
+	for _, q := range questions {
+		q.ID = uuid.NewString()
+		q.TimesUsed = 0
+		q.LastUsedAt = nil
+		if err := uc.questionRepo.Create(&q); err != nil {
+			return nil, err
+		}
+		res = append(res, &q)
+	}
+	return res, nil
+}
+
+func parseQuestionsCSV(data []byte) ([]domain.Question, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := cols["text"]; !ok {
+		return nil, fmt.Errorf("invalid csv: missing \"text\" column")
+	}
+
+	var questions []domain.Question
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+		field := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		q := domain.Question{
+			Text:       field("text"),
+			Category:   field("category"),
+			Difficulty: domain.Difficulty(strings.ToLower(field("difficulty"))),
+			Language:   domain.Language(strings.ToLower(field("language"))),
+		}
+		if tags := field("tags"); tags != "" {
+			q.Tags = strings.Split(tags, ";")
+		}
+		questions = append(questions, q)
+	}
+	return questions, nil
+}
+
+// Pick escolhe a pergunta da próxima ronda do jogo (ver Game.PickQuestion).
+// Devolve nil se o banco não tiver nenhuma pergunta que sirva.
+func (uc *QuestionBankUseCase) Pick(game *domain.Game) (*domain.Question, error) {
+	questions, err := uc.questionRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	return game.PickQuestion(questions), nil
+}
+
+// Get devolve a pergunta do banco.
+func (uc *QuestionBankUseCase) Get(id string) (*domain.Question, error) {
+	return uc.questionRepo.Get(id)
+}
+
+// MarkUsed regista que a pergunta foi usada numa ronda.
+func (uc *QuestionBankUseCase) MarkUsed(id string) error {
+	uc.usedMu.Lock()
+	defer uc.usedMu.Unlock()
+
+	q, err := uc.questionRepo.Get(id)
+	if err != nil {
+		return err
+	}
+	used := *q
+	now := time.Now()
+	used.TimesUsed++
+	used.LastUsedAt = &now
+	return uc.questionRepo.Update(&used)
+}
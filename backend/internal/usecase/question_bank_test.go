@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+func TestQuestionBankSeed(t *testing.T) {
+	uc := NewQuestionBankUseCase(repository.NewInMemoryQuestionRepository())
+
+	n, err := uc.Seed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := len(domain.DefaultQuestions()); n != want {
+		t.Fatalf("Seed() = %d, want %d", n, want)
+	}
+	for _, lang := range domain.Languages {
+		questions, err := uc.List(nil, lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(questions) == 0 {
+			t.Errorf("sem perguntas embutidas em %s", lang)
+		}
+	}
+
+	// Um banco que já tem perguntas fica como está
+	if n, err := uc.Seed(); err != nil || n != 0 {
+		t.Errorf("segundo Seed() = %d, %v, want 0, nil", n, err)
+	}
+}
+
+// Jogos em paralelo não perdem utilizações da mesma pergunta.
+func TestQuestionBankMarkUsedConcurrent(t *testing.T) {
+	uc := NewQuestionBankUseCase(repository.NewInMemoryQuestionRepository())
+	q, err := uc.Create(domain.Question{Text: "Porquê?"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const games = 50
+	var wg sync.WaitGroup
+	for i := 0; i < games; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := uc.MarkUsed(q.ID); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, _ := uc.Get(q.ID)
+	if got.TimesUsed != games || got.LastUsedAt == nil {
+		t.Errorf("TimesUsed = %d, want %d", got.TimesUsed, games)
+	}
+}
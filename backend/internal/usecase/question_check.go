@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// Limites por omissão das perguntas escritas pelos jogadores.
+const (
+	DefaultQuestionMinLength = 5
+	DefaultQuestionMaxLength = 300
+)
+
+var ErrQuestionRejected = errors.New("question rejected")
+
+// Motivos de rejeição de uma pergunta.
+const (
+	RejectTooShort        = "too_short"
+	RejectTooLong         = "too_long"
+	RejectControlChars    = "control_characters"
+	RejectPromptInjection = "prompt_injection"
+	RejectUnsafe          = "unsafe"
+)
+
+// QuestionRejection explica porque é que uma pergunta foi recusada.
+type QuestionRejection struct {
+	Reasons []RejectReason `json:"reasons"`
+}
+
+type RejectReason struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
+
+func (r *QuestionRejection) Error() string {
+	details := make([]string, 0, len(r.Reasons))
+	for _, reason := range r.Reasons {
+		details = append(details, reason.Detail)
+	}
+	return ErrQuestionRejected.Error() + ": " + strings.Join(details, "; ")
+}
+
+func (r *QuestionRejection) Is(target error) bool {
+	return target == ErrQuestionRejected
+}
+
+// QuestionPolicy define as verificações feitas às perguntas dos jogadores
+// antes de entrarem nos prompts.
+type QuestionPolicy struct {
+	MinLength int  // em caracteres; 0 = DefaultQuestionMinLength
+	MaxLength int  // em caracteres; 0 = DefaultQuestionMaxLength
+	Classify  bool // pedir também ao LLM para classificar a pergunta
+}
+
+// injectionPatterns apanham as tentativas mais comuns de mudar as regras do
+// jogo a partir da pergunta, em português e em inglês.
+var injectionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(ignor\w*|esquec\w*|forget|disregard|desconsider\w*)\b.{0,40}\b(regras?|instru[çc][õo]es|rules?|instructions?|prompts?|tudo|everything|above|acima|anteriores|previous)\b`),
+	regexp.MustCompile(`(?i)\b(vot[ae]m?|vote|votes|voting|elimin\w*)\b.{0,20}\b(em|no|na|for|against|contra)\s+(o\s+|a\s+)?agent-\d+`),
+	regexp.MustCompile(`(?i)\b(novas regras|new rules|a partir de agora|from now on|you are now|agora és|act as|finge que és|pretend (to be|you are))\b`),
+	regexp.MustCompile(`(?i)(^|\s)(system|assistant|sistema)\s*:|<\|[a-z_]+\|>|\[/?INST\]|</?(pergunta|question|system)>`),
+}
+
+// checkQuestion valida e normaliza a pergunta de um jogador. Devolve um
+// *QuestionRejection (ErrQuestionRejected) quando a pergunta é recusada.
+func (uc *PlayRoundUseCase) checkQuestion(ctx context.Context, game *domain.Game, question string) (string, error) {
+	policy := uc.questionPolicy
+	minLen, maxLen := policy.MinLength, policy.MaxLength
+	if minLen <= 0 {
+		minLen = DefaultQuestionMinLength
+	}
+	if maxLen <= 0 {
+		maxLen = DefaultQuestionMaxLength
+	}
+
+	var rejection QuestionRejection
+	reject := func(code, detail string) {
+		rejection.Reasons = append(rejection.Reasons, RejectReason{Code: code, Detail: detail})
+	}
+
+	if strings.ContainsFunc(question, func(r rune) bool {
+		return unicode.IsControl(r) && r != '\n' && r != '\t' && r != '\r'
+	}) {
+		reject(RejectControlChars, "question contains control characters")
+	}
+
+	// Uma só linha: as quebras de linha servem sobretudo para fingir secções do prompt
+	question = strings.Join(strings.Fields(question), " ")
+
+	switch n := utf8.RuneCountInString(question); {
+	case n < minLen:
+		reject(RejectTooShort, fmt.Sprintf("question must have at least %d characters", minLen))
+	case n > maxLen:
+		reject(RejectTooLong, fmt.Sprintf("question must have at most %d characters", maxLen))
+	}
+
+	for _, re := range injectionPatterns {
+		if re.MatchString(question) {
+			reject(RejectPromptInjection, "question looks like an attempt to change the game rules")
+			break
+		}
+	}
+
+	if len(rejection.Reasons) == 0 && policy.Classify {
+		allowed, reason, err := uc.groq.ClassifyQuestion(ctx, game, question)
+		if err != nil {
+			return "", err
+		}
+		if !allowed {
+			reject(RejectUnsafe, reason)
+		}
+	}
+
+	if len(rejection.Reasons) > 0 {
+		return "", &rejection
+	}
+	return question, nil
+}
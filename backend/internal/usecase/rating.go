@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+type LeaderboardInput struct {
+	Filter domain.RatingFilter
+	Limit  int // 0 = todas
+}
+
+type RecomputeRatingsOutput struct {
+	Games   int `json:"games"`   // jogos contados
+	Ratings int `json:"ratings"` // pares (modelo, persona) classificados
+}
+
+// RatingUseCase mantém as classificações Elo de cada par (modelo, persona),
+// atualizadas no fim de cada jogo.
+type RatingUseCase struct {
+	ratingRepo repository.RatingRepository
+	gameRepo   repository.GameRepository
+
+	mu sync.Mutex // Record e Recompute leem e gravam a tabela inteira
+}
+
+func NewRatingUseCase(ratingRepo repository.RatingRepository, gameRepo repository.GameRepository) *RatingUseCase {
+	return &RatingUseCase{ratingRepo: ratingRepo, gameRepo: gameRepo}
+}
+
+// Record conta um jogo terminado. Um jogo já contado é ignorado.
+func (uc *RatingUseCase) Record(game *domain.Game) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	table, err := uc.ratingRepo.Load()
+	if err != nil {
+		return err
+	}
+	if !table.Rate(game) {
+		return nil
+	}
+	return uc.ratingRepo.Save(table)
+}
+
+// Recompute refaz a tabela a partir dos jogos guardados, pela ordem em que
+// acabaram (ex: depois de mudar a fórmula ou de apagar a tabela).
+func (uc *RatingUseCase) Recompute() (*RecomputeRatingsOutput, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	games, err := uc.gameRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(games, func(i, j int) bool {
+		a, b := games[i].FinishedAt, games[j].FinishedAt
+		switch {
+		case a == nil || b == nil:
+			// Jogos antigos, sem data, primeiro
+			if (a == nil) != (b == nil) {
+				return a == nil
+			}
+		case !a.Equal(*b):
+			return a.Before(*b)
+		}
+		return games[i].ID < games[j].ID
+	})
+
+	table := domain.NewRatingTable()
+	out := &RecomputeRatingsOutput{}
+	for _, game := range games {
+		if table.Rate(game) {
+			out.Games++
+		}
+	}
+	out.Ratings = len(table.Ratings)
+
+	if err := uc.ratingRepo.Save(table); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Leaderboard devolve as classificações que passam o filtro, da melhor para a pior.
+func (uc *RatingUseCase) Leaderboard(input LeaderboardInput) ([]domain.Rating, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	table, err := uc.ratingRepo.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]domain.Rating, 0, len(table.Ratings))
+	for _, r := range table.Ratings {
+		if input.Filter.Matches(r) {
+			rating := *r
+			rating.Rating = math.Round(rating.Rating*10) / 10
+			rating.AvgPlacement = math.Round(rating.AvgPlacement*100) / 100
+			res = append(res, rating)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Rating != res[j].Rating {
+			return res[i].Rating > res[j].Rating
+		}
+		if res[i].Model != res[j].Model {
+			return res[i].Model < res[j].Model
+		}
+		return res[i].Persona < res[j].Persona
+	})
+	if input.Limit > 0 && len(res) > input.Limit {
+		res = res[:input.Limit]
+	}
+	return res, nil
+}
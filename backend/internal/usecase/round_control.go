@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+var (
+	ErrRoundInProgress = errors.New("a round is already in progress")
+	ErrRoundPaused     = errors.New("the current round is paused; resume or cancel it first")
+	ErrRoundFailed     = errors.New("the current round failed; retry or cancel it first")
+	ErrNoCurrentRound  = errors.New("no round in progress")
+	ErrRoundNotFailed  = errors.New("the current round has not failed")
+)
+
+// Causas usadas para interromper a ronda em curso.
+var (
+	errPauseRequested  = errors.New("round paused")
+	errCancelRequested = errors.New("round cancelled")
+)
+
+type roundPausedPayload struct {
+	Round *domain.Round `json:"round"`
+}
+
+type roundCancelledPayload struct {
+	Round int `json:"round"`
+}
+
+// startRun regista a ronda em curso do jogo, para poder ser posta em pausa ou
+// cancelada. Só pode haver uma por jogo; release tem de ser chamado no fim.
+func (uc *PlayRoundUseCase) startRun(ctx context.Context, gameID string) (context.Context, func(), error) {
+	uc.runsMu.Lock()
+	defer uc.runsMu.Unlock()
+	if _, ok := uc.runs[gameID]; ok {
+		return nil, nil, ErrRoundInProgress
+	}
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	uc.runs[gameID] = cancel
+
+	release := func() {
+		uc.runsMu.Lock()
+		delete(uc.runs, gameID)
+		uc.runsMu.Unlock()
+		cancel(nil)
+	}
+	return runCtx, release, nil
+}
+
+func (uc *PlayRoundUseCase) isRunning(gameID string) bool {
+	uc.runsMu.Lock()
+	defer uc.runsMu.Unlock()
+	_, ok := uc.runs[gameID]
+	return ok
+}
+
+// currentRoundError explica porque é que não se pode começar outra ronda
+// enquanto o jogo tem uma ronda por terminar.
+func (uc *PlayRoundUseCase) currentRoundError(game *domain.Game) error {
+	switch {
+	case uc.isRunning(game.ID):
+		return ErrRoundInProgress
+	case game.CurrentRound.State == domain.RoundStateFailed:
+		return ErrRoundFailed
+	}
+	return ErrRoundPaused
+}
+
+// PauseRound pede a pausa da ronda em curso. A ronda pára na intervenção
+// atual (que é descartada) e o que já foi jogado fica guardado para Resume.
+func (uc *PlayRoundUseCase) PauseRound(gameID string) error {
+	game, err := uc.gameRepo.Get(gameID)
+	if err != nil {
+		return err
+	}
+
+	uc.runsMu.Lock()
+	defer uc.runsMu.Unlock()
+	if cancel, ok := uc.runs[gameID]; ok {
+		cancel(errPauseRequested)
+		return nil
+	}
+	if game.CurrentRound != nil {
+		return uc.currentRoundError(game)
+	}
+	return ErrNoCurrentRound
+}
+
+// CancelRound descarta a ronda em curso (ou em pausa), sem strikes nem
+// eliminações.
+func (uc *PlayRoundUseCase) CancelRound(gameID string) error {
+	game, err := uc.gameRepo.Get(gameID)
+	if err != nil {
+		return err
+	}
+
+	uc.runsMu.Lock()
+	defer uc.runsMu.Unlock()
+	if cancel, ok := uc.runs[gameID]; ok {
+		cancel(errCancelRequested)
+		return nil
+	}
+	if game.CurrentRound == nil {
+		return ErrNoCurrentRound
+	}
+	game.CurrentRound = nil
+	return uc.gameRepo.Update(game)
+}
+
+// Resume retoma a ronda em pausa a partir do passo onde ficou. Uma ronda que
+// ficou "running" sem estar a correr (o servidor foi reiniciado a meio) também
+// pode ser retomada.
+func (uc *PlayRoundUseCase) Resume(ctx context.Context, gameID string, onEvent RoundEventFunc) (*PlayRoundOutput, error) {
+	return uc.continueRound(ctx, gameID, false, onEvent)
+}
+
+// Retry repete a ronda que falhou (ex: o LLM continuou a falhar depois dos
+// retries) a partir do passo que falhou, mantendo tudo o que já foi jogado.
+func (uc *PlayRoundUseCase) Retry(ctx context.Context, gameID string, onEvent RoundEventFunc) (*PlayRoundOutput, error) {
+	return uc.continueRound(ctx, gameID, true, onEvent)
+}
+
+func (uc *PlayRoundUseCase) continueRound(ctx context.Context, gameID string, failed bool, onEvent RoundEventFunc) (*PlayRoundOutput, error) {
+	game, err := uc.gameRepo.Get(gameID)
+	if err != nil {
+		return nil, err
+	}
+	round := game.CurrentRound
+	if round == nil {
+		return nil, ErrNoCurrentRound
+	}
+	if uc.isRunning(gameID) {
+		return nil, ErrRoundInProgress
+	}
+	switch {
+	case failed && round.State != domain.RoundStateFailed:
+		return nil, ErrRoundNotFailed
+	case !failed && round.State == domain.RoundStateFailed:
+		return nil, ErrRoundFailed
+	}
+
+	if onEvent != nil {
+		event := "round_resumed"
+		if failed {
+			event = "round_retried"
+		}
+		onEvent(event, roundPausedPayload{Round: round})
+	}
+	return uc.run(ctx, game, round, onEvent)
+}
+
+// interrupted trata uma ronda que parou antes do fim: cancelada é descartada,
+// em pausa ou com erro fica guardada para ser retomada ou repetida.
+func (uc *PlayRoundUseCase) interrupted(runCtx context.Context, game *domain.Game, round *domain.Round, err error, emit RoundEventFunc) (*PlayRoundOutput, error) {
+	switch cause := context.Cause(runCtx); {
+	case errors.Is(cause, errPauseRequested):
+		round.State = domain.RoundStatePaused
+		if err := uc.gameRepo.Update(game); err != nil {
+			return nil, err
+		}
+		emit("round_paused", roundPausedPayload{Round: round})
+		return &PlayRoundOutput{Game: game, Round: round}, nil
+
+	case errors.Is(cause, errCancelRequested):
+		game.CurrentRound = nil
+		if err := uc.gameRepo.Update(game); err != nil {
+			return nil, err
+		}
+		emit("round_cancelled", roundCancelledPayload{Round: round.Index})
+		return &PlayRoundOutput{Game: game, Round: round}, nil
+	}
+
+	round.State = domain.RoundStateFailed
+	round.Error = err.Error()
+	if updateErr := uc.gameRepo.Update(game); updateErr != nil {
+		return nil, updateErr
+	}
+	return nil, err
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+)
+
+// roundSteps percorre os passos de uma ronda (cada resposta, cada mensagem de
+// debate, cada voto, ...), avança o cursor da ronda à medida que terminam e
+// grava a ronda depois de cada um (checkpoint). Numa ronda retomada os passos
+// antes do cursor são saltados, por isso cada passo tem de deixar na ronda
+// tudo o que os passos seguintes precisam.
+type roundSteps struct {
+	ctx        context.Context
+	round      *domain.Round
+	checkpoint func() error
+	next       int
+}
+
+func newRoundSteps(ctx context.Context, round *domain.Round, checkpoint func() error) *roundSteps {
+	if round.Cursor == nil {
+		round.Cursor = &domain.RoundCursor{}
+	}
+	return &roundSteps{ctx: ctx, round: round, checkpoint: checkpoint}
+}
+
+// do corre o passo seguinte, a não ser que já tenha sido concluído.
+func (s *roundSteps) do(phase string, fn func() error) error {
+	i := s.next
+	s.next++
+	if i < s.round.Cursor.Step {
+		return nil
+	}
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	s.round.Cursor.Phase = phase
+	if err := fn(); err != nil {
+		return err
+	}
+	s.round.Cursor.Step = i + 1
+	return s.checkpoint()
+}
@@ -0,0 +1,368 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+	"github.com/rafawastaken/ai-hunger-games/internal/service"
+)
+
+var errGroqDown = errors.New("groq down")
+
+// stepGroq responde sempre o mesmo ao mesmo pedido e falha na chamada failAt
+// (contada a partir de 1; 0 = nunca falha).
+type stepGroq struct {
+	budget int // orçamento da transcrição; pequeno obriga a resumir o debate
+	failAt int
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (g *stepGroq) call(ctx context.Context) error {
+	g.mu.Lock()
+	g.calls++
+	n := g.calls
+	g.mu.Unlock()
+	if n == g.failAt {
+		return errGroqDown
+	}
+	return ctx.Err()
+}
+
+func (g *stepGroq) GenerateAnswer(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, error) {
+	return "resposta de " + agent.ID, g.call(ctx)
+}
+
+func (g *stepGroq) GenerateDebateMessage(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent) (string, error) {
+	return fmt.Sprintf("%s depois de %d mensagens", agent.ID, len(round.Debate)), g.call(ctx)
+}
+
+// GenerateVote vota no agente seguinte pela ordem de criação (3 agentes
+// empatam todos) ou, às cegas, na primeira entrada do boletim.
+func (g *stepGroq) GenerateVote(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, ballot *domain.BlindBallot) (string, string, error) {
+	if err := g.call(ctx); err != nil {
+		return "", "", err
+	}
+	if ballot != nil {
+		ballot.Choice = ballot.Entries[0].Label
+		return ballot.Entries[0].AgentID, "às cegas", nil
+	}
+	active := game.ActiveAgents()
+	for i, a := range active {
+		if a.ID == agent.ID {
+			return active[(i+1)%len(active)].ID, "o seguinte", nil
+		}
+	}
+	return "", "", fmt.Errorf("voter %s not active", agent.ID)
+}
+
+func (g *stepGroq) GenerateJudgeVote(ctx context.Context, game *domain.Game, round *domain.Round, tiedAgents []string) (string, string, error) {
+	return tiedAgents[len(tiedAgents)-1], "o último", g.call(ctx)
+}
+
+func (g *stepGroq) TranscriptBudget(game *domain.Game) int { return g.budget }
+
+func (g *stepGroq) SummarizeDebate(ctx context.Context, game *domain.Game, round *domain.Round, upToTurn int) (string, error) {
+	return fmt.Sprintf("resumo até ao turno %d", upToTurn), g.call(ctx)
+}
+
+func (g *stepGroq) PromptVersion(game *domain.Game) (string, error) { return "pt@1", nil }
+
+func (g *stepGroq) GenerateQuestion(ctx context.Context, game *domain.Game) (string, error) {
+	return "Qual é a melhor cor?", g.call(ctx)
+}
+
+func (g *stepGroq) ClassifyQuestion(ctx context.Context, game *domain.Game, question string) (bool, string, error) {
+	return true, "", g.call(ctx)
+}
+
+func (g *stepGroq) ClassifyMessage(ctx context.Context, game *domain.Game, text string) (bool, string, error) {
+	return true, "", g.call(ctx)
+}
+
+func (g *stepGroq) GenerateFinaleStatement(ctx context.Context, game *domain.Game, round *domain.Round, agent *domain.Agent, stage domain.FinaleStage) (string, error) {
+	return fmt.Sprintf("%s na fase %s", agent.ID, stage), g.call(ctx)
+}
+
+func (g *stepGroq) GenerateFinaleJudgeVerdict(ctx context.Context, game *domain.Game, round *domain.Round, judge int) (string, string, error) {
+	return round.Finale.Finalists[judge%2], fmt.Sprintf("juiz %d", judge+1), g.call(ctx)
+}
+
+// GenerateJuryVote divide o júri: os jurados alternam entre os finalistas.
+func (g *stepGroq) GenerateJuryVote(ctx context.Context, game *domain.Game, round *domain.Round, juror *domain.Agent) (string, string, error) {
+	for i, a := range game.EliminatedAgents() {
+		if a.ID == juror.ID {
+			return round.Finale.Finalists[i%2], "jurado " + juror.ID, g.call(ctx)
+		}
+	}
+	return "", "", fmt.Errorf("juror %s not eliminated", juror.ID)
+}
+
+// stepCase é um jogo cuja próxima ronda é jogada de uma vez (referência) e
+// depois interrompida em cada ponto possível.
+type stepCase struct {
+	name       string
+	game       CreateGameInput
+	eliminated []string // agentes já eliminados antes da ronda (para a final)
+	moderation ModerationPolicy
+	budget     int
+}
+
+// stepRun é o resultado de jogar a ronda até ao fim.
+type stepRun struct {
+	events []string // nome e payload dos eventos, sem os de controlo
+	round  string   // a ronda terminada, em JSON
+	agents string   // strikes e eliminações, em JSON
+	winner string
+	calls  int
+}
+
+// controlEvents são emitidos a cada interrupção e não contam para a comparação.
+var controlEvents = map[string]bool{
+	"round_paused":  true,
+	"round_resumed": true,
+	"round_retried": true,
+}
+
+func (c stepCase) newUseCase(t *testing.T, repo repository.GameRepository, groq service.GroqService) *PlayRoundUseCase {
+	t.Helper()
+	return NewPlayRoundUseCase(repo, groq, NewQuestionBankUseCase(repository.NewInMemoryQuestionRepository()), QuestionPolicy{}, c.moderation, nil)
+}
+
+// setup cria o jogo num repositório em ficheiro (para a ronda poder ser
+// retomada por outro "servidor") e deixa-o pronto para a ronda a testar.
+func (c stepCase) setup(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := repository.NewFileGameRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prompts, err := service.LoadPromptLibrary("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := NewCreateGameUseCase(repo, repository.NewInMemoryPersonaRepository(), prompts, "m").Execute(c.game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := out.Game
+	for _, id := range c.eliminated {
+		game.Eliminate(game.Agent(id), &domain.Round{Index: 1})
+	}
+	if err := repo.Update(game); err != nil {
+		t.Fatal(err)
+	}
+	return dir, game.ID
+}
+
+// reload abre o repositório outra vez, como um servidor acabado de arrancar.
+func reload(t *testing.T, dir string) repository.GameRepository {
+	t.Helper()
+	repo, err := repository.NewFileGameRepository(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func recordEvents(events *[]string) RoundEventFunc {
+	return func(event string, payload any) {
+		if controlEvents[event] {
+			return
+		}
+		if event == "round_end" || event == "game_end" {
+			// Levam o jogo inteiro, com a hora a que acabou
+			*events = append(*events, event)
+			return
+		}
+		data, _ := json.Marshal(payload)
+		*events = append(*events, event+" "+string(data))
+	}
+}
+
+func (c stepCase) result(t *testing.T, repo repository.GameRepository, gameID string, events []string, calls int) stepRun {
+	t.Helper()
+	game, err := repo.Get(gameID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if game.CurrentRound != nil {
+		t.Fatalf("a ronda não terminou: estado %s, erro %q", game.CurrentRound.State, game.CurrentRound.Error)
+	}
+	round, _ := json.Marshal(game.Rounds[len(game.Rounds)-1])
+	agents, _ := json.Marshal(game.Agents)
+	return stepRun{events: events, round: string(round), agents: string(agents), winner: game.WinnerID, calls: calls}
+}
+
+const stepQuestion = "Qual é a melhor estação do ano?"
+
+// reference joga a ronda sem interrupções.
+func (c stepCase) reference(t *testing.T) stepRun {
+	t.Helper()
+	dir, gameID := c.setup(t)
+	repo := reload(t, dir)
+	groq := &stepGroq{budget: c.budget}
+	var events []string
+	_, err := c.newUseCase(t, repo, groq).Execute(context.Background(), PlayRoundInput{GameID: gameID, Question: stepQuestion, OnEvent: recordEvents(&events)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.result(t, repo, gameID, events, groq.calls)
+}
+
+// failAndRetry faz falhar a chamada n ao LLM e repete a ronda noutro servidor.
+func (c stepCase) failAndRetry(t *testing.T, n int) stepRun {
+	t.Helper()
+	dir, gameID := c.setup(t)
+	var events []string
+
+	repo := reload(t, dir)
+	_, err := c.newUseCase(t, repo, &stepGroq{budget: c.budget, failAt: n}).Execute(context.Background(), PlayRoundInput{GameID: gameID, Question: stepQuestion, OnEvent: recordEvents(&events)})
+	if !errors.Is(err, errGroqDown) {
+		t.Fatalf("Execute: err = %v, want %v", err, errGroqDown)
+	}
+
+	repo = reload(t, dir)
+	if game, _ := repo.Get(gameID); game.CurrentRound == nil || game.CurrentRound.State != domain.RoundStateFailed {
+		t.Fatalf("a ronda devia ter ficado com estado failed: %+v", game.CurrentRound)
+	}
+	if _, err := c.newUseCase(t, repo, &stepGroq{budget: c.budget}).Retry(context.Background(), gameID, recordEvents(&events)); err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	return c.result(t, repo, gameID, events, 0)
+}
+
+// pauseAndResume põe a ronda em pausa quando sai o evento k e retoma-a
+// noutro servidor.
+func (c stepCase) pauseAndResume(t *testing.T, k int) stepRun {
+	t.Helper()
+	dir, gameID := c.setup(t)
+	var events []string
+	record := recordEvents(&events)
+
+	repo := reload(t, dir)
+	uc := c.newUseCase(t, repo, &stepGroq{budget: c.budget})
+	seen := 0
+	_, err := uc.Execute(context.Background(), PlayRoundInput{GameID: gameID, Question: stepQuestion, OnEvent: func(event string, payload any) {
+		record(event, payload)
+		if seen++; seen == k {
+			if err := uc.PauseRound(gameID); err != nil {
+				t.Errorf("PauseRound: %v", err)
+			}
+		}
+	}})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	repo = reload(t, dir)
+	game, _ := repo.Get(gameID)
+	if game.CurrentRound != nil {
+		// A pausa pedida durante o último passo chega tarde e a ronda acaba
+		if game.CurrentRound.State != domain.RoundStatePaused {
+			t.Fatalf("a ronda devia ter ficado em pausa: %+v", game.CurrentRound)
+		}
+		if _, err := c.newUseCase(t, repo, &stepGroq{budget: c.budget}).Resume(context.Background(), gameID, record); err != nil {
+			t.Fatalf("Resume: %v", err)
+		}
+	}
+	return c.result(t, repo, gameID, events, 0)
+}
+
+func compareRuns(t *testing.T, got, want stepRun) {
+	t.Helper()
+	if !slices.Equal(got.events, want.events) {
+		t.Errorf("eventos diferentes da referência:\ngot:  %q\nwant: %q", got.events, want.events)
+	}
+	if got.round != want.round {
+		t.Errorf("ronda diferente da referência:\ngot:  %s\nwant: %s", got.round, want.round)
+	}
+	if got.agents != want.agents || got.winner != want.winner {
+		t.Errorf("agentes diferentes da referência:\ngot:  %s (%s)\nwant: %s (%s)", got.agents, got.winner, want.agents, want.winner)
+	}
+}
+
+func TestRoundStepsResumeAndRetry(t *testing.T) {
+	shuffle := domain.SpeakingOrder{
+		domain.PhaseAnswer: domain.OrderShuffle,
+		domain.PhaseDebate: domain.OrderShuffle,
+		domain.PhaseVote:   domain.OrderShuffle,
+		domain.PhaseFinale: domain.OrderShuffle,
+	}
+
+	cases := []stepCase{
+		{
+			// Empate a três (juiz), moderação e resumos do debate
+			name:       "ronda com empate",
+			game:       CreateGameInput{NumAgents: 3, Seed: 1},
+			moderation: ModerationPolicy{Blocklist: []string{"agent-2"}, Classify: true},
+			budget:     1,
+		},
+		{
+			name:   "votação cega baralhada",
+			game:   CreateGameInput{NumAgents: 4, Seed: 7, BlindVoting: true, SpeakingOrder: shuffle, RoundConfig: domain.RoundConfig{DebateTurns: 3}},
+			budget: 1 << 20,
+		},
+		{
+			name:       "final com juízes",
+			game:       CreateGameInput{NumAgents: 4, Seed: 3, SpeakingOrder: shuffle},
+			eliminated: []string{"agent-2", "agent-4"},
+			budget:     1 << 20,
+		},
+		{
+			// Júri dividido: o vencedor sai de um juiz de desempate
+			name:       "final com júri empatado",
+			game:       CreateGameInput{NumAgents: 4, Seed: 3, FinaleDecider: domain.FinaleDeciderJury},
+			eliminated: []string{"agent-1", "agent-3"},
+			moderation: ModerationPolicy{Classify: true},
+			budget:     1 << 20,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ref := c.reference(t)
+			if ref.calls == 0 || len(ref.events) == 0 {
+				t.Fatalf("referência vazia: %+v", ref)
+			}
+
+			for n := 1; n <= ref.calls; n++ {
+				t.Run(fmt.Sprintf("falha na chamada %d", n), func(t *testing.T) {
+					compareRuns(t, c.failAndRetry(t, n), ref)
+				})
+			}
+			for k := 1; k <= len(ref.events); k++ {
+				t.Run(fmt.Sprintf("pausa no evento %d", k), func(t *testing.T) {
+					compareRuns(t, c.pauseAndResume(t, k), ref)
+				})
+			}
+		})
+	}
+}
+
+// Sanidade dos casos: cada um passa pelo caminho que quer testar.
+func TestRoundStepsCases(t *testing.T) {
+	tie := stepCase{game: CreateGameInput{NumAgents: 3, Seed: 1}, budget: 1}
+	ref := tie.reference(t)
+	if !slices.ContainsFunc(ref.events, func(e string) bool { return e == `phase {"phase":"judge"}` }) {
+		t.Errorf("o empate não chamou o juiz: %q", ref.events)
+	}
+
+	jury := stepCase{game: CreateGameInput{NumAgents: 4, Seed: 3, FinaleDecider: domain.FinaleDeciderJury}, eliminated: []string{"agent-1", "agent-3"}, budget: 1 << 20}
+	ref = jury.reference(t)
+	if !slices.ContainsFunc(ref.events, func(e string) bool { return e == `phase {"phase":"finale_tiebreak"}` }) {
+		t.Errorf("o júri empatado não chamou o desempate: %q", ref.events)
+	}
+	if ref.winner == "" {
+		t.Errorf("a final não teve vencedor")
+	}
+}
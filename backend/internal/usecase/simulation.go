@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+// SimulationRoster é um elenco de agentes IA: a persona e o modelo de cada lugar.
+type SimulationRoster struct {
+	Name     string
+	Personas []domain.Persona
+	Models   []string
+}
+
+type SimulationInput struct {
+	Games        int
+	Concurrency  int                // jogos em simultâneo (0 = 1)
+	Rosters      []SimulationRoster // o jogo i usa Rosters[i % len(Rosters)]
+	Game         CreateGameInput    // configuração comum; com Seed != 0 o jogo i usa Seed+i
+	Source       QuestionSource
+	Questions    []string
+	MaxRounds    int
+	RoundTimeout time.Duration
+	OnGame       func(SimulationGameResult) // opcional, chamado quando cada jogo acaba
+}
+
+// SimulationGameResult é o resultado de um jogo da simulação.
+type SimulationGameResult struct {
+	Index      int                     `json:"index"`
+	GameID     string                  `json:"game_id,omitempty"`
+	Roster     string                  `json:"roster"`
+	Seed       int64                   `json:"seed"`
+	Rounds     int                     `json:"rounds"`
+	Ties       int                     `json:"ties"` // rondas decididas pelo juiz, contando a final desempatada
+	Finished   bool                    `json:"finished"`
+	StopReason string                  `json:"stop_reason,omitempty"` // razão do fim do autoplay
+	EndReason  domain.EndReason        `json:"end_reason,omitempty"`
+	WinnerID   string                  `json:"winner_id,omitempty"`
+	Error      string                  `json:"error,omitempty"`
+	Agents     []SimulationAgentResult `json:"agents,omitempty"`
+}
+
+type SimulationAgentResult struct {
+	AgentID         string `json:"agent_id"`
+	Persona         string `json:"persona"`
+	Model           string `json:"model"`
+	Placement       int    `json:"placement,omitempty"` // 0 se o jogo não acabou
+	EliminatedRound int    `json:"eliminated_round,omitempty"`
+	RoundsSurvived  int    `json:"rounds_survived"`
+	Strikes         int    `json:"strikes"`
+	VotesReceived   int    `json:"votes_received"`
+	Winner          bool   `json:"winner"`
+}
+
+// SimulationStats agrega os resultados de todos os jogos.
+type SimulationStats struct {
+	Games    int          `json:"games"`
+	Finished int          `json:"finished"`
+	Failed   int          `json:"failed"`
+	Rounds   int          `json:"rounds"`
+	TieRate  float64      `json:"tie_rate"` // rondas com empate / rondas jogadas
+	Models   []GroupStats `json:"models"`
+	Personas []GroupStats `json:"personas"`
+}
+
+// GroupStats são as estatísticas de um modelo ou persona nos jogos acabados.
+type GroupStats struct {
+	Name         string  `json:"name"`
+	Appearances  int     `json:"appearances"` // lugares ocupados
+	Wins         int     `json:"wins"`
+	WinRate      float64 `json:"win_rate"`
+	AvgSurvival  float64 `json:"avg_survival"` // fração das rondas do jogo em que sobreviveu
+	AvgPlacement float64 `json:"avg_placement"`
+}
+
+type SimulationOutput struct {
+	Results []SimulationGameResult `json:"results"`
+	Stats   SimulationStats        `json:"stats"`
+}
+
+// SimulationUseCase joga muitos jogos completos seguidos, sem HTTP, para
+// estudar como os modelos se portam no jogo.
+type SimulationUseCase struct {
+	gameRepo   repository.GameRepository
+	createGame *CreateGameUseCase
+	autoplay   *AutoplayUseCase
+}
+
+func NewSimulationUseCase(repo repository.GameRepository, createGame *CreateGameUseCase, autoplay *AutoplayUseCase) *SimulationUseCase {
+	return &SimulationUseCase{gameRepo: repo, createGame: createGame, autoplay: autoplay}
+}
+
+// Execute joga os jogos com no máximo Concurrency em simultâneo. Um jogo que
+// falha fica nos resultados com o erro e não pára a simulação; cancelar o ctx
+// pára-a e os jogos que não chegaram a começar não entram nos resultados.
+func (uc *SimulationUseCase) Execute(ctx context.Context, input SimulationInput) (*SimulationOutput, error) {
+	if input.Games <= 0 {
+		return nil, fmt.Errorf("games must be positive")
+	}
+	if len(input.Rosters) == 0 {
+		return nil, fmt.Errorf("at least one roster is required")
+	}
+	for _, roster := range input.Rosters {
+		if len(roster.Personas) < 2 {
+			return nil, fmt.Errorf("roster %q: at least 2 agents are required", roster.Name)
+		}
+	}
+	concurrency := input.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make([]*SimulationGameResult, input.Games)
+	var mu sync.Mutex // protege OnGame
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+launch:
+	for i := 0; i < input.Games; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res := uc.playGame(ctx, input, i)
+			results[i] = &res
+			if input.OnGame != nil {
+				mu.Lock()
+				input.OnGame(res)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	out := &SimulationOutput{}
+	for _, res := range results {
+		if res != nil {
+			out.Results = append(out.Results, *res)
+		}
+	}
+	out.Stats = simulationStats(out.Results)
+	return out, ctx.Err()
+}
+
+func (uc *SimulationUseCase) playGame(ctx context.Context, input SimulationInput, i int) SimulationGameResult {
+	roster := input.Rosters[i%len(input.Rosters)]
+	res := SimulationGameResult{Index: i + 1, Roster: roster.Name}
+
+	gameInput := input.Game
+	gameInput.NumAgents = len(roster.Personas)
+	gameInput.PersonaIDs = nil
+	gameInput.Personas = roster.Personas
+	gameInput.Models = roster.Models
+	gameInput.HumanPlayers = nil
+	if gameInput.Seed != 0 {
+		gameInput.Seed += int64(i)
+	}
+
+	created, err := uc.createGame.Execute(gameInput)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	game := created.Game
+	res.GameID = game.ID
+	res.Seed = game.Seed
+
+	finaleTie := false
+	autoplay, err := uc.autoplay.Execute(ctx, AutoplayInput{
+		GameID:       game.ID,
+		Source:       input.Source,
+		Questions:    input.Questions,
+		MaxRounds:    input.MaxRounds,
+		RoundTimeout: input.RoundTimeout,
+		OnEvent: func(event string, payload any) {
+			switch event {
+			case "judge_vote":
+				res.Ties++
+			case "phase":
+				if p, ok := payload.(map[string]string); ok && p["phase"] == "finale_tiebreak" && !finaleTie {
+					finaleTie = true
+					res.Ties++
+				}
+			}
+		},
+	})
+	if err != nil {
+		res.Error = err.Error()
+	} else {
+		res.StopReason = autoplay.Reason
+	}
+
+	// O jogo pode ter jogado algumas rondas antes de falhar
+	if game, err = uc.gameRepo.Get(game.ID); err != nil {
+		return res
+	}
+	res.Rounds = len(game.Rounds)
+	res.Finished = game.Status == domain.GameStatusFinished
+	res.EndReason = game.EndReason
+	res.WinnerID = game.WinnerID
+
+	placements := make(map[string]int, len(game.Standings))
+	for _, s := range game.Standings {
+		placements[s.AgentID] = s.Placement
+	}
+	votes := game.VotesReceived()
+	for _, a := range game.Agents {
+		ar := SimulationAgentResult{
+			AgentID:         a.ID,
+			Persona:         a.Name,
+			Model:           a.Model,
+			Placement:       placements[a.ID],
+			EliminatedRound: a.EliminatedRound,
+			RoundsSurvived:  len(game.Rounds),
+			Strikes:         a.Strikes,
+			VotesReceived:   votes[a.ID],
+			Winner:          a.ID == game.WinnerID,
+		}
+		if a.Eliminated {
+			ar.RoundsSurvived = a.EliminatedRound
+		}
+		res.Agents = append(res.Agents, ar)
+	}
+	return res
+}
+
+// simulationStats agrega os resultados. As estatísticas por modelo e persona
+// só contam os jogos que acabaram.
+func simulationStats(results []SimulationGameResult) SimulationStats {
+	stats := SimulationStats{Games: len(results)}
+	models := make(map[string]*groupAcc)
+	personas := make(map[string]*groupAcc)
+	ties := 0
+
+	for _, res := range results {
+		if res.Error != "" {
+			stats.Failed++
+		}
+		stats.Rounds += res.Rounds
+		ties += res.Ties
+		if !res.Finished || res.Rounds == 0 {
+			continue
+		}
+		stats.Finished++
+		for _, a := range res.Agents {
+			survival := float64(a.RoundsSurvived) / float64(res.Rounds)
+			accFor(models, a.Model).add(a, survival)
+			accFor(personas, a.Persona).add(a, survival)
+		}
+	}
+	if stats.Rounds > 0 {
+		stats.TieRate = float64(ties) / float64(stats.Rounds)
+	}
+	stats.Models = groupStats(models)
+	stats.Personas = groupStats(personas)
+	return stats
+}
+
+type groupAcc struct {
+	appearances, wins    int
+	survival, placements float64
+}
+
+func accFor(groups map[string]*groupAcc, key string) *groupAcc {
+	acc, ok := groups[key]
+	if !ok {
+		acc = &groupAcc{}
+		groups[key] = acc
+	}
+	return acc
+}
+
+func (acc *groupAcc) add(a SimulationAgentResult, survival float64) {
+	acc.appearances++
+	if a.Winner {
+		acc.wins++
+	}
+	acc.survival += survival
+	acc.placements += float64(a.Placement)
+}
+
+// groupStats ordena por taxa de vitória e depois por sobrevivência média.
+func groupStats(groups map[string]*groupAcc) []GroupStats {
+	res := make([]GroupStats, 0, len(groups))
+	for name, acc := range groups {
+		n := float64(acc.appearances)
+		res = append(res, GroupStats{
+			Name:         name,
+			Appearances:  acc.appearances,
+			Wins:         acc.wins,
+			WinRate:      float64(acc.wins) / n,
+			AvgSurvival:  acc.survival / n,
+			AvgPlacement: acc.placements / n,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].WinRate != res[j].WinRate {
+			return res[i].WinRate > res[j].WinRate
+		}
+		if res[i].AvgSurvival != res[j].AvgSurvival {
+			return res[i].AvgSurvival > res[j].AvgSurvival
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
@@ -0,0 +1,492 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+var (
+	ErrTournamentFinished  = errors.New("tournament already finished")
+	ErrTournamentAdvancing = errors.New("tournament is already advancing")
+)
+
+// defaultGroupSize é o número de agentes por grupo quando não é indicado.
+const defaultGroupSize = 4
+
+type CreateTournamentInput struct {
+	Name       string
+	Groups     int              // número de grupos (pelo menos 2)
+	GroupSize  int              // agentes por grupo (0 = defaultGroupSize)
+	PersonaIDs []string         // personas da biblioteca, distribuídas pelos grupos por ordem
+	Personas   []domain.Persona // personas dos lugares seguintes, o resto usa as embutidas
+	Game       CreateGameInput  // configuração de todos os jogos (Models vale para cada grupo); agentes e humanos são ignorados
+}
+
+type AdvanceTournamentInput struct {
+	TournamentID string
+	Source       QuestionSource // como no autoplay
+	Questions    []string
+	MaxRounds    int
+	RoundTimeout time.Duration
+	OnEvent      RoundEventFunc
+}
+
+type AdvanceTournamentOutput struct {
+	Tournament *domain.Tournament `json:"tournament"`
+	GameID     string             `json:"game_id"` // jogo jogado neste avanço
+	Autoplay   *AutoplayOutput    `json:"autoplay"`
+}
+
+type tournamentGamePayload struct {
+	Stage  string `json:"stage"`           // group ou final
+	Group  int    `json:"group,omitempty"` // só nos grupos
+	GameID string `json:"game_id"`
+}
+
+// TournamentUseCase cria torneios e avança-os jogo a jogo, com o autoplay.
+type TournamentUseCase struct {
+	tournamentRepo repository.TournamentRepository
+	gameRepo       repository.GameRepository
+	personaRepo    repository.PersonaRepository
+	createGame     *CreateGameUseCase
+	autoplay       *AutoplayUseCase
+
+	// mu protege advancing e o refresh dos torneios guardados: ler, copiar
+	// os vencedores e gravar é feito de uma vez, para dois pedidos ao mesmo
+	// tempo não se sobreporem (e um deles perder a final criada pelo outro).
+	mu        sync.Mutex
+	advancing map[string]bool // torneio -> a avançar
+}
+
+func NewTournamentUseCase(
+	tournamentRepo repository.TournamentRepository,
+	gameRepo repository.GameRepository,
+	personaRepo repository.PersonaRepository,
+	createGame *CreateGameUseCase,
+	autoplay *AutoplayUseCase,
+) *TournamentUseCase {
+	return &TournamentUseCase{
+		tournamentRepo: tournamentRepo,
+		gameRepo:       gameRepo,
+		personaRepo:    personaRepo,
+		createGame:     createGame,
+		autoplay:       autoplay,
+		advancing:      make(map[string]bool),
+	}
+}
+
+// Create cria o torneio e o jogo de cada grupo. As personas são distribuídas
+// pelos grupos por ordem (as primeiras GroupSize no grupo 1, ...) e os lugares
+// que sobram usam as personas embutidas, com a numeração a continuar de grupo
+// para grupo (para os grupos não terem todos as mesmas).
+func (uc *TournamentUseCase) Create(input CreateTournamentInput) (*domain.Tournament, error) {
+	if input.Groups < 2 {
+		return nil, fmt.Errorf("a tournament needs at least 2 groups")
+	}
+	if input.GroupSize == 0 {
+		input.GroupSize = defaultGroupSize
+	}
+	if input.GroupSize < 2 {
+		return nil, fmt.Errorf("group_size must be at least 2")
+	}
+
+	personas := make([]domain.Persona, 0, input.Groups*input.GroupSize)
+	for _, id := range input.PersonaIDs {
+		p, err := uc.personaRepo.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, id)
+		}
+		personas = append(personas, *p)
+	}
+	personas = append(personas, input.Personas...)
+	if len(personas) > input.Groups*input.GroupSize {
+		return nil, fmt.Errorf("too many personas: %d groups of %d take at most %d", input.Groups, input.GroupSize, input.Groups*input.GroupSize)
+	}
+	lang := input.Game.Language
+	if lang == "" {
+		lang = domain.DefaultLanguage
+	}
+	for i := len(personas); i < input.Groups*input.GroupSize; i++ {
+		personas = append(personas, *domain.DefaultPersona(lang, i))
+	}
+
+	tournament := &domain.Tournament{
+		ID:     uuid.NewString(),
+		Name:   strings.TrimSpace(input.Name),
+		Status: domain.TournamentStatusGroups,
+	}
+	for g := 0; g < input.Groups; g++ {
+		gameInput := input.Game
+		gameInput.NumAgents = input.GroupSize
+		gameInput.PersonaIDs = nil
+		gameInput.Personas = personas[g*input.GroupSize : (g+1)*input.GroupSize]
+		gameInput.HumanPlayers = nil
+
+		out, err := uc.createGame.Execute(gameInput)
+		if err != nil {
+			uc.removeGames(tournament.Groups)
+			return nil, fmt.Errorf("group %d: %w", g+1, err)
+		}
+		tournament.Groups = append(tournament.Groups, &domain.TournamentGame{
+			Group:  g + 1,
+			GameID: out.Game.ID,
+		})
+	}
+
+	if err := uc.tournamentRepo.Create(tournament); err != nil {
+		uc.removeGames(tournament.Groups)
+		return nil, err
+	}
+	return copyTournament(tournament), nil
+}
+
+// removeGames apaga os jogos dos grupos de um torneio que não chegou a ser
+// criado, para não ficarem órfãos na lista de jogos.
+func (uc *TournamentUseCase) removeGames(games []*domain.TournamentGame) {
+	for _, tg := range games {
+		_ = uc.gameRepo.Delete(tg.GameID)
+	}
+}
+
+// Get devolve o torneio com os vencedores dos jogos que entretanto acabaram
+// (os jogos também podem ser jogados ronda a ronda em /games/{id}).
+func (uc *TournamentUseCase) Get(id string) (*domain.Tournament, error) {
+	return uc.sync(id, false)
+}
+
+// sync faz o refresh do torneio guardado e, com withFinal, cria a final se
+// os grupos já acabaram, gravando-o se mudou. Corre com uc.mu e devolve uma
+// cópia, que quem chama pode ler sem concorrer com o próximo refresh.
+func (uc *TournamentUseCase) sync(id string, withFinal bool) (*domain.Tournament, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	tournament, err := uc.tournamentRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := uc.refresh(tournament)
+	if err != nil {
+		return nil, err
+	}
+	if withFinal && tournament.Final == nil && tournament.GroupsDone() {
+		if err := uc.createFinal(tournament); err != nil {
+			return nil, err
+		}
+		changed = true
+	}
+	if changed {
+		if err := uc.tournamentRepo.Update(tournament); err != nil {
+			return nil, err
+		}
+	}
+	return copyTournament(tournament), nil
+}
+
+func copyTournament(t *domain.Tournament) *domain.Tournament {
+	c := *t
+	c.Groups = make([]*domain.TournamentGame, len(t.Groups))
+	for i, g := range t.Groups {
+		gc := *g
+		c.Groups[i] = &gc
+	}
+	if t.Final != nil {
+		final := *t.Final
+		c.Final = &final
+	}
+	if t.Winner != nil {
+		winner := *t.Winner
+		c.Winner = &winner
+	}
+	return &c
+}
+
+func (uc *TournamentUseCase) List() ([]*domain.Tournament, error) {
+	tournaments, err := uc.tournamentRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*domain.Tournament, 0, len(tournaments))
+	for _, t := range tournaments {
+		t, err := uc.Get(t.ID)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+// Advance joga o próximo jogo do torneio até acabar, com o autoplay: o
+// primeiro grupo sem vencedor ou, com todos os grupos decididos, a final
+// (criada aqui com os vencedores). Os eventos do jogo vêm depois de um
+// tournament_game e o avanço acaba com tournament_advanced.
+func (uc *TournamentUseCase) Advance(ctx context.Context, input AdvanceTournamentInput) (*AdvanceTournamentOutput, error) {
+	emit := input.OnEvent
+	if emit == nil {
+		emit = func(string, any) {}
+	}
+
+	release, err := uc.startAdvance(input.TournamentID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	tournament, err := uc.sync(input.TournamentID, true)
+	if err != nil {
+		return nil, err
+	}
+	if tournament.Status == domain.TournamentStatusFinished {
+		return nil, ErrTournamentFinished
+	}
+
+	next := tournament.NextGame()
+	stage := "group"
+	if next == tournament.Final {
+		stage = "final"
+	}
+	emit("tournament_game", tournamentGamePayload{Stage: stage, Group: next.Group, GameID: next.GameID})
+
+	autoplay, err := uc.autoplay.Execute(ctx, AutoplayInput{
+		GameID:       next.GameID,
+		Source:       input.Source,
+		Questions:    input.Questions,
+		MaxRounds:    input.MaxRounds,
+		RoundTimeout: input.RoundTimeout,
+		OnEvent:      emit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A final fica logo criada quando o último grupo acaba, para o bracket a mostrar
+	if tournament, err = uc.sync(tournament.ID, true); err != nil {
+		return nil, err
+	}
+
+	bracket, err := uc.Bracket(tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+	emit("tournament_advanced", bracket)
+
+	return &AdvanceTournamentOutput{
+		Tournament: tournament,
+		GameID:     next.GameID,
+		Autoplay:   autoplay,
+	}, nil
+}
+
+func (uc *TournamentUseCase) startAdvance(id string) (func(), error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	if uc.advancing[id] {
+		return nil, ErrTournamentAdvancing
+	}
+	uc.advancing[id] = true
+
+	return func() {
+		uc.mu.Lock()
+		delete(uc.advancing, id)
+		uc.mu.Unlock()
+	}, nil
+}
+
+// refresh copia para o torneio os vencedores dos jogos que já acabaram.
+func (uc *TournamentUseCase) refresh(tournament *domain.Tournament) (bool, error) {
+	changed := false
+	games := tournament.Groups
+	if tournament.Final != nil {
+		games = append(games[:len(games):len(games)], tournament.Final)
+	}
+	for _, tg := range games {
+		if tg.WinnerID != "" {
+			continue
+		}
+		game, err := uc.gameRepo.Get(tg.GameID)
+		if err != nil {
+			return false, err
+		}
+		if game.Status == domain.GameStatusFinished && game.WinnerID != "" {
+			tg.WinnerID = game.WinnerID
+			changed = true
+		}
+	}
+
+	if final := tournament.Final; final != nil && final.WinnerID != "" && tournament.Status != domain.TournamentStatusFinished {
+		game, err := uc.gameRepo.Get(final.GameID)
+		if err != nil {
+			return false, err
+		}
+		if winner := game.Agent(final.WinnerID); winner != nil && winner.Persona != nil {
+			p := *winner.Persona
+			tournament.Winner = &p
+		}
+		tournament.Status = domain.TournamentStatusFinished
+		changed = true
+	}
+	return changed, nil
+}
+
+// createFinal cria o jogo da final com os vencedores dos grupos (persona e
+// modelo), pela ordem dos grupos, e a mesma configuração dos jogos dos grupos.
+// Não grava o torneio: isso fica para o sync.
+func (uc *TournamentUseCase) createFinal(tournament *domain.Tournament) error {
+	var personas []domain.Persona
+	var models []string
+	var first *domain.Game
+	for _, tg := range tournament.Groups {
+		game, err := uc.gameRepo.Get(tg.GameID)
+		if err != nil {
+			return err
+		}
+		if first == nil {
+			first = game
+		}
+		winner := game.Agent(tg.WinnerID)
+		if winner == nil || winner.Persona == nil {
+			return fmt.Errorf("group %d: winner %s not found", tg.Group, tg.WinnerID)
+		}
+		personas = append(personas, *winner.Persona)
+		models = append(models, winner.Model)
+	}
+
+	out, err := uc.createGame.Execute(CreateGameInput{
+		NumAgents:       len(personas),
+		Personas:        personas,
+		Models:          models,
+		MaxStrikes:      first.MaxStrikes,
+		FinaleDecider:   first.FinaleDecider,
+		HumanTimeout:    first.HumanTimeout,
+		AudienceWindow:  first.AudienceWindow,
+		AudienceWeight:  &first.AudienceWeight,
+		Language:        first.Language,
+		PromptSet:       first.PromptSet,
+		Theme:           first.Theme,
+		ProvocativeHost: first.ProvocativeHost,
+		SpeakingOrder:   first.SpeakingOrder,
+		BlindVoting:     first.BlindVoting,
+		RoundConfig:     first.RoundConfig,
+		QuestionFilter:  first.QuestionFilter,
+	})
+	if err != nil {
+		return err
+	}
+
+	tournament.Final = &domain.TournamentGame{GameID: out.Game.ID}
+	tournament.Status = domain.TournamentStatusFinal
+	return nil
+}
+
+// === Bracket ===
+
+// TournamentBracket é a vista do torneio com o estado de cada jogo e dos
+// seus participantes.
+type TournamentBracket struct {
+	TournamentID string                  `json:"tournament_id"`
+	Name         string                  `json:"name,omitempty"`
+	Status       domain.TournamentStatus `json:"status"`
+	Groups       []BracketGame           `json:"groups"`
+	Final        *BracketGame            `json:"final,omitempty"`
+	Winner       *domain.Persona         `json:"winner,omitempty"`
+}
+
+type BracketGame struct {
+	Group        int               `json:"group,omitempty"`
+	GameID       string            `json:"game_id"`
+	Status       domain.GameStatus `json:"status"`
+	RoundsPlayed int               `json:"rounds_played"`
+	WinnerID     string            `json:"winner_id,omitempty"`
+	Entrants     []BracketEntrant  `json:"entrants"`
+}
+
+type BracketEntrant struct {
+	AgentID    string `json:"agent_id"`
+	Name       string `json:"name"`
+	PersonaID  string `json:"persona_id,omitempty"`
+	FromGroup  int    `json:"from_group,omitempty"` // só na final
+	Strikes    int    `json:"strikes"`
+	Eliminated bool   `json:"eliminated"`
+	Placement  int    `json:"placement,omitempty"` // quando o jogo acabou
+	Advanced   bool   `json:"advanced,omitempty"`  // venceu o grupo (ou o torneio)
+}
+
+// Bracket devolve a vista do torneio, montada a partir dos jogos.
+func (uc *TournamentUseCase) Bracket(id string) (*TournamentBracket, error) {
+	tournament, err := uc.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	bracket := &TournamentBracket{
+		TournamentID: tournament.ID,
+		Name:         tournament.Name,
+		Status:       tournament.Status,
+		Winner:       tournament.Winner,
+	}
+	for _, tg := range tournament.Groups {
+		bg, err := uc.bracketGame(tg, false)
+		if err != nil {
+			return nil, err
+		}
+		bracket.Groups = append(bracket.Groups, *bg)
+	}
+	if tournament.Final != nil {
+		if bracket.Final, err = uc.bracketGame(tournament.Final, true); err != nil {
+			return nil, err
+		}
+	}
+	return bracket, nil
+}
+
+func (uc *TournamentUseCase) bracketGame(tg *domain.TournamentGame, final bool) (*BracketGame, error) {
+	game, err := uc.gameRepo.Get(tg.GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	placements := make(map[string]int, len(game.Standings))
+	for _, s := range game.Standings {
+		placements[s.AgentID] = s.Placement
+	}
+
+	bg := &BracketGame{
+		Group:        tg.Group,
+		GameID:       game.ID,
+		Status:       game.Status,
+		RoundsPlayed: len(game.Rounds),
+		WinnerID:     tg.WinnerID,
+	}
+	for i, a := range game.Agents {
+		e := BracketEntrant{
+			AgentID:    a.ID,
+			Name:       a.Name,
+			Strikes:    a.Strikes,
+			Eliminated: a.Eliminated,
+			Placement:  placements[a.ID],
+			Advanced:   a.ID == tg.WinnerID,
+		}
+		if a.Persona != nil {
+			e.PersonaID = a.Persona.ID
+		}
+		if final {
+			e.FromGroup = i + 1
+		}
+		bg.Entrants = append(bg.Entrants, e)
+	}
+	sort.SliceStable(bg.Entrants, func(i, j int) bool {
+		pi, pj := bg.Entrants[i].Placement, bg.Entrants[j].Placement
+		return pi != 0 && (pj == 0 || pi < pj)
+	})
+	return bg, nil
+}
@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/rafawastaken/ai-hunger-games/internal/domain"
+	"github.com/rafawastaken/ai-hunger-games/internal/repository"
+)
+
+// newTestTournament cria um torneio de 2 grupos de 2, com as personas p1-p4
+// e um modelo diferente em cada lugar.
+func newTestTournament(t *testing.T) (*TournamentUseCase, repository.GameRepository, *domain.Tournament) {
+	t.Helper()
+	createGame := newTestCreateGame(t)
+	uc := NewTournamentUseCase(repository.NewInMemoryTournamentRepository(), createGame.gameRepo, repository.NewInMemoryPersonaRepository(), createGame, nil)
+	tournament, err := uc.Create(CreateTournamentInput{
+		Groups:    2,
+		GroupSize: 2,
+		Personas: []domain.Persona{
+			{ID: "p1", Name: "Ana"}, {ID: "p2", Name: "Bia"},
+			{ID: "p3", Name: "Caio"}, {ID: "p4", Name: "Duda"},
+		},
+		Game: CreateGameInput{Models: []string{"m-a", "m-b"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uc, createGame.gameRepo, tournament
+}
+
+// finishGame acaba o jogo com os agentes classificados pela ordem dada: o
+// primeiro vence e os restantes ficam eliminados.
+func finishGame(t *testing.T, repo repository.GameRepository, gameID string, placed ...string) {
+	t.Helper()
+	game, err := repo.Get(gameID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.Status = domain.GameStatusFinished
+	game.WinnerID = placed[0]
+	for i, id := range placed {
+		game.Standings = append(game.Standings, domain.Standing{AgentID: id, Placement: i + 1})
+		game.Agent(id).Eliminated = i > 0
+	}
+	if err := repo.Update(game); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTournamentRefresh(t *testing.T) {
+	uc, games, tournament := newTestTournament(t)
+
+	got, err := uc.Get(tournament.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != domain.TournamentStatusGroups || got.Groups[0].WinnerID != "" || got.Groups[1].WinnerID != "" {
+		t.Fatalf("sem jogos acabados: %+v", got)
+	}
+
+	// Um jogo a meio não conta
+	game, _ := games.Get(tournament.Groups[0].GameID)
+	game.Status = domain.GameStatusRunning
+	_ = games.Update(game)
+	if got, _ = uc.Get(tournament.ID); got.Groups[0].WinnerID != "" {
+		t.Errorf("jogo a meio deu vencedor: %q", got.Groups[0].WinnerID)
+	}
+
+	finishGame(t, games, tournament.Groups[0].GameID, "agent-2", "agent-1")
+	finishGame(t, games, tournament.Groups[1].GameID, "agent-1", "agent-2")
+	if got, err = uc.Get(tournament.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got.Groups[0].WinnerID != "agent-2" || got.Groups[1].WinnerID != "agent-1" {
+		t.Errorf("vencedores dos grupos: %q, %q", got.Groups[0].WinnerID, got.Groups[1].WinnerID)
+	}
+	if got.Final != nil || got.Status != domain.TournamentStatusGroups {
+		t.Errorf("Get não cria a final: %+v", got)
+	}
+
+	if got, err = uc.sync(tournament.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	if got.Final == nil || got.Status != domain.TournamentStatusFinal {
+		t.Fatalf("final por criar: %+v", got)
+	}
+
+	finishGame(t, games, got.Final.GameID, "agent-2", "agent-1")
+	if got, err = uc.Get(tournament.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != domain.TournamentStatusFinished || got.Final.WinnerID != "agent-2" {
+		t.Fatalf("torneio por acabar: %+v", got)
+	}
+	// O agent-2 da final é o vencedor do grupo 2 (Caio)
+	if got.Winner == nil || got.Winner.ID != "p3" {
+		t.Errorf("Winner = %+v, want p3", got.Winner)
+	}
+	if next := got.NextGame(); next != nil {
+		t.Errorf("NextGame num torneio acabado: %+v", next)
+	}
+}
+
+func TestTournamentCreateFinal(t *testing.T) {
+	uc, games, tournament := newTestTournament(t)
+	finishGame(t, games, tournament.Groups[0].GameID, "agent-2", "agent-1")
+	finishGame(t, games, tournament.Groups[1].GameID, "agent-1", "agent-2")
+
+	got, err := uc.sync(tournament.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	final, err := games.Get(got.Final.GameID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// agent-N na final é o vencedor do grupo N, com a persona e o modelo que tinha
+	tests := []struct {
+		agentID string
+		persona string
+		model   string
+	}{
+		{"agent-1", "p2", "m-b"},
+		{"agent-2", "p3", "m-a"},
+	}
+	if len(final.Agents) != len(tests) {
+		t.Fatalf("final com %d agentes, want %d", len(final.Agents), len(tests))
+	}
+	for _, tt := range tests {
+		a := final.Agent(tt.agentID)
+		if a == nil || a.Persona == nil {
+			t.Fatalf("%s em falta na final", tt.agentID)
+		}
+		if a.Persona.ID != tt.persona || a.Model != tt.model {
+			t.Errorf("%s: persona %s, modelo %s; want %s, %s", tt.agentID, a.Persona.ID, a.Model, tt.persona, tt.model)
+		}
+	}
+
+	// Um segundo sync não cria outra final
+	again, err := uc.sync(tournament.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Final.GameID != got.Final.GameID {
+		t.Errorf("final recriada: %s, era %s", again.Final.GameID, got.Final.GameID)
+	}
+}
+
+func TestTournamentBracketEntrants(t *testing.T) {
+	uc, games, tournament := newTestTournament(t)
+	finishGame(t, games, tournament.Groups[0].GameID, "agent-2", "agent-1")
+
+	bracket, err := uc.Bracket(tournament.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		game      BracketGame
+		want      []string
+		advanced  string
+		fromGroup bool
+	}{
+		// Jogo acabado: pela classificação
+		{name: "grupo 1", game: bracket.Groups[0], want: []string{"agent-2", "agent-1"}, advanced: "agent-2"},
+		// Sem classificação: pela ordem dos lugares
+		{name: "grupo 2", game: bracket.Groups[1], want: []string{"agent-1", "agent-2"}},
+	}
+
+	finishGame(t, games, tournament.Groups[1].GameID, "agent-1", "agent-2")
+	if _, err := uc.sync(tournament.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := uc.Get(tournament.ID)
+	finishGame(t, games, got.Final.GameID, "agent-2", "agent-1")
+	if bracket, err = uc.Bracket(tournament.ID); err != nil {
+		t.Fatal(err)
+	}
+	tests = append(tests, struct {
+		name      string
+		game      BracketGame
+		want      []string
+		advanced  string
+		fromGroup bool
+	}{name: "final", game: *bracket.Final, want: []string{"agent-2", "agent-1"}, advanced: "agent-2", fromGroup: true})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			for i, e := range tt.game.Entrants {
+				ids = append(ids, e.AgentID)
+				if e.Placement != 0 && e.Placement != i+1 {
+					t.Errorf("%s: placement %d na posição %d", e.AgentID, e.Placement, i+1)
+				}
+				if e.Advanced != (e.AgentID == tt.advanced) {
+					t.Errorf("%s: advanced = %v", e.AgentID, e.Advanced)
+				}
+				// FromGroup segue o lugar (agent-N = grupo N), não a posição na lista
+				wantGroup := 0
+				if tt.fromGroup {
+					wantGroup = int(e.AgentID[len(e.AgentID)-1] - '0')
+				}
+				if e.FromGroup != wantGroup {
+					t.Errorf("%s: from_group %d, want %d", e.AgentID, e.FromGroup, wantGroup)
+				}
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("entrants %v, want %v", ids, tt.want)
+			}
+		})
+	}
+	if bracket.Status != domain.TournamentStatusFinished || bracket.Winner == nil || bracket.Winner.ID != "p3" {
+		t.Errorf("bracket final: status %s, winner %+v", bracket.Status, bracket.Winner)
+	}
+}
+
+// Leituras ao mesmo tempo que a final é criada não a podem apagar.
+func TestTournamentConcurrentReads(t *testing.T) {
+	uc, games, tournament := newTestTournament(t)
+	finishGame(t, games, tournament.Groups[0].GameID, "agent-1", "agent-2")
+	finishGame(t, games, tournament.Groups[1].GameID, "agent-1", "agent-2")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := uc.List(); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := uc.Bracket(tournament.ID); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	got, err := uc.sync(tournament.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	after, err := uc.Get(tournament.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Final == nil || after.Final.GameID != got.Final.GameID {
+		t.Errorf("final perdida: %+v, want %s", after.Final, got.Final.GameID)
+	}
+}
+
+// failingGameRepo falha o Create a partir do jogo número failAt.
+type failingGameRepo struct {
+	*repository.InMemoryGameRepository
+	created, failAt int
+}
+
+func (r *failingGameRepo) Create(game *domain.Game) error {
+	if r.created++; r.created >= r.failAt {
+		return errors.New("disco cheio")
+	}
+	return r.InMemoryGameRepository.Create(game)
+}
+
+func TestTournamentCreateRemovesGroupGames(t *testing.T) {
+	repo := &failingGameRepo{InMemoryGameRepository: repository.NewInMemoryGameRepository(), failAt: 3}
+	createGame := newTestCreateGame(t)
+	createGame.gameRepo = repo
+	uc := NewTournamentUseCase(repository.NewInMemoryTournamentRepository(), repo, repository.NewInMemoryPersonaRepository(), createGame, nil)
+
+	if _, err := uc.Create(CreateTournamentInput{Groups: 3, GroupSize: 2}); err == nil {
+		t.Fatal("Create com o grupo 3 a falhar devia dar erro")
+	}
+	if left, _ := repo.List(); len(left) != 0 {
+		t.Errorf("ficaram %d jogos de grupos órfãos", len(left))
+	}
+	if tournaments, _ := uc.List(); len(tournaments) != 0 {
+		t.Errorf("torneio criado apesar do erro: %d", len(tournaments))
+	}
+}